@@ -42,6 +42,9 @@ type Endpoint[B Boundary] struct {
 	// after B. When used as an interval ending point, it corresponds to an
 	// inclusive end bound. When used as an interval starting point, it
 	// corresponds to an exclusive start bound.
+	//
+	// If PlusEpsilon is false, the boundary is considered to be infinitesimally
+	// before B (an exclusive end bound or an inclusive start bound).
 	PlusEpsilon bool
 }
 
@@ -96,3 +99,133 @@ func EndpointCompareFn[B Boundary](bCmp CompareFn[B]) CompareFn[Endpoint[B]] {
 		}
 	}
 }
+
+// Compare compares the endpoint with another endpoint, using the given
+// comparison function for the underlying boundaries; see EndpointCompareFn.
+func (e Endpoint[B]) Compare(other Endpoint[B], bCmp CompareFn[B]) int {
+	return EndpointCompareFn(bCmp)(e, other)
+}
+
+// Min returns the smaller of the two endpoints.
+func (e Endpoint[B]) Min(other Endpoint[B], bCmp CompareFn[B]) Endpoint[B] {
+	if e.Compare(other, bCmp) <= 0 {
+		return e
+	}
+	return other
+}
+
+// Max returns the larger of the two endpoints.
+func (e Endpoint[B]) Max(other Endpoint[B], bCmp CompareFn[B]) Endpoint[B] {
+	if e.Compare(other, bCmp) >= 0 {
+		return e
+	}
+	return other
+}
+
+// Before returns the endpoint immediately before e.B. As a start endpoint, it
+// includes e.B; as an end endpoint, it excludes e.B.
+func (e Endpoint[B]) Before() Endpoint[B] {
+	return Endpoint[B]{B: e.B, PlusEpsilon: false}
+}
+
+// After returns the endpoint immediately after e.B. As a start endpoint, it
+// excludes e.B; as an end endpoint, it includes e.B.
+func (e Endpoint[B]) After() Endpoint[B] {
+	return Endpoint[B]{B: e.B, PlusEpsilon: true}
+}
+
+// Negate returns the endpoint at the mirrored position, given a function that
+// negates boundaries (i.e. reverses their order): the B value is negated and
+// the endpoint moves to the other side of it. The order of negated endpoints is
+// reversed, so an interval [start, end) becomes
+// [end.Negate(neg), start.Negate(neg)); for example, [1, 5) becomes (-5, -1].
+func (e Endpoint[B]) Negate(neg func(B) B) Endpoint[B] {
+	return Endpoint[B]{B: neg(e.B), PlusEpsilon: !e.PlusEpsilon}
+}
+
+// StartType returns the type of the endpoint when used as an interval start
+// point; it is the inverse of MakeStartEndpoint.
+func (e Endpoint[B]) StartType() InclusiveOrExclusive {
+	return InclusiveIf(!e.PlusEpsilon)
+}
+
+// EndType returns the type of the endpoint when used as an interval end point;
+// it is the inverse of MakeEndEndpoint.
+func (e Endpoint[B]) EndType() InclusiveOrExclusive {
+	return InclusiveIf(e.PlusEpsilon)
+}
+
+// Discrete is implemented by adapters for discrete boundary types (like
+// integers or fixed-length keys), where every boundary has an immediate
+// successor and predecessor.
+type Discrete[B Boundary] interface {
+	// Next returns the smallest boundary greater than b. It must not be called
+	// with the largest boundary.
+	Next(b B) B
+	// Prev returns the largest boundary smaller than b. It must not be called
+	// with the smallest boundary.
+	Prev(b B) B
+}
+
+// Integer is a constraint for integer boundary types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// MakeIntegerDiscrete returns the Discrete adapter for an integer type.
+func MakeIntegerDiscrete[I Integer]() Discrete[I] {
+	return integerDiscrete[I]{}
+}
+
+type integerDiscrete[I Integer] struct{}
+
+func (integerDiscrete[I]) Next(b I) I { return b + 1 }
+func (integerDiscrete[I]) Prev(b I) I { return b - 1 }
+
+// DiscreteBoundary converts an Endpoint into the equivalent plain boundary, for
+// a discrete boundary type. An endpoint that is infinitesimally after B is
+// equivalent to the boundary d.Next(B); otherwise it is equivalent to B.
+//
+// This is the canonicalization that all the helpers below are based on.
+func DiscreteBoundary[B Boundary](d Discrete[B], e Endpoint[B]) B {
+	if e.PlusEpsilon {
+		return d.Next(e.B)
+	}
+	return e.B
+}
+
+// DiscreteInterval converts an interval with Endpoint boundaries (with
+// arbitrary inclusive or exclusive ends) into the equivalent half-open
+// interval, for a discrete boundary type; see DiscreteBoundary.
+//
+// For example, with integers the interval (1, 5] becomes [2, 6).
+func DiscreteInterval[B Boundary](d Discrete[B], start, end Endpoint[B]) Interval[B] {
+	return Interval[B]{Start: DiscreteBoundary(d, start), End: DiscreteBoundary(d, end)}
+}
+
+// ClosedInterval returns the half-open interval equivalent to the closed
+// interval [first, last], for a discrete boundary type.
+func ClosedInterval[B Boundary](d Discrete[B], first, last B) Interval[B] {
+	start, end := MakeEndpoints(first, Inclusive, last, Inclusive)
+	return DiscreteInterval(d, start, end)
+}
+
+// ClosedBounds returns the first and last boundary of a non-empty half-open
+// interval, for a discrete boundary type; it is the inverse of ClosedInterval.
+func ClosedBounds[B Boundary](d Discrete[B], i Interval[B]) (first, last B) {
+	return i.Start, d.Prev(i.End)
+}
+
+// CanonicalEndpoint returns the canonical form of an Endpoint, for a discrete
+// boundary type: the Endpoint without PlusEpsilon which is equivalent to e (see
+// DiscreteBoundary). For example, with integers both (1, ...) and [2, ...)
+// start at [2, ...).
+//
+// It can be used as a region tree's NormalizeBoundary option for
+// Endpoint[B] boundaries, so that equivalent intervals like [1, 2] and [1, 3)
+// produce the same boundaries, and regions like [1, 2] and [3, 5] are
+// recognized as adjacent (and coalesced if they have the same property).
+func CanonicalEndpoint[B Boundary](d Discrete[B], e Endpoint[B]) Endpoint[B] {
+	return Endpoint[B]{B: DiscreteBoundary(d, e)}
+}
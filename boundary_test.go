@@ -0,0 +1,103 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axisds
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestDiscreteInterval(t *testing.T) {
+	iFmt := MakeIntervalFormatter(MakeBoundaryFormatter[int]())
+	d := MakeIntegerDiscrete[int]()
+	str := func(start, end Endpoint[int]) string {
+		i := DiscreteInterval(d, start, end)
+		return iFmt(i.Start, i.End)
+	}
+	expect(t, str(MakeEndpoints(1, Inclusive, 5, Inclusive)), "[1, 6)")
+	expect(t, str(MakeEndpoints(1, Inclusive, 5, Exclusive)), "[1, 5)")
+	expect(t, str(MakeEndpoints(1, Exclusive, 5, Inclusive)), "[2, 6)")
+	expect(t, str(MakeEndpoints(1, Exclusive, 5, Exclusive)), "[2, 5)")
+}
+
+func TestEndpointMethods(t *testing.T) {
+	c := cmp.Compare[int]
+	a := MakeStartEndpoint(1, Inclusive)
+	b := MakeStartEndpoint(1, Exclusive)
+	d := MakeEndEndpoint(2, Exclusive)
+	expect(t, a.Compare(b, c), -1)
+	expect(t, b.Compare(a, c), +1)
+	expect(t, b.Compare(b, c), 0)
+	expect(t, b.Compare(d, c), -1)
+	expect(t, a.Min(b, c), a)
+	expect(t, b.Min(a, c), a)
+	expect(t, a.Max(b, c), b)
+	expect(t, d.Max(b, c), d)
+
+	expect(t, a.After(), b)
+	expect(t, b.After(), b)
+	expect(t, a.Before(), a)
+	expect(t, b.Before(), a)
+	expect(t, a, Endpoint[int]{B: 1, PlusEpsilon: false})
+	expect(t, b, Endpoint[int]{B: 1, PlusEpsilon: true})
+
+	neg := func(x int) int { return -x }
+	iFmt := MakeEndpointIntervalFormatter(MakeBoundaryFormatter[int]())
+	for _, tc := range []struct {
+		startTyp, endTyp InclusiveOrExclusive
+		expected         string
+	}{
+		{Inclusive, Exclusive, "(-5, -1]"},
+		{Exclusive, Inclusive, "[-5, -1)"},
+		{Inclusive, Inclusive, "[-5, -1]"},
+		{Exclusive, Exclusive, "(-5, -1)"},
+	} {
+		s, e := MakeEndpoints(1, tc.startTyp, 5, tc.endTyp)
+		ns, ne := e.Negate(neg), s.Negate(neg)
+		expect(t, ns.Compare(ne, c), -1)
+		expect(t, iFmt(ns, ne), tc.expected)
+		expect(t, ns.Negate(neg), e)
+		expect(t, ne.Negate(neg), s)
+	}
+
+	for _, typ := range []InclusiveOrExclusive{Inclusive, Exclusive} {
+		expect(t, MakeStartEndpoint(5, typ).StartType(), typ)
+		expect(t, MakeEndEndpoint(5, typ).EndType(), typ)
+	}
+}
+
+func TestDiscrete(t *testing.T) {
+	d := MakeIntegerDiscrete[int]()
+	c := cmp.Compare[int]
+	i := ClosedInterval(d, 1, 5)
+	expect(t, i, Interval[int]{Start: 1, End: 6})
+	first, last := ClosedBounds(d, i)
+	expect(t, first, 1)
+	expect(t, last, 5)
+	expect(t, i.Adjacent(ClosedInterval(d, 6, 7), c), true)
+	expect(t, i.Adjacent(ClosedInterval(d, 7, 7), c), false)
+	expect(t, i.Adjacent(ClosedInterval(d, -3, 0), c), true)
+	expect(t, i.Adjacent(Interval[int]{Start: 6, End: 6}, c), false)
+
+	canonical := func(start, end Endpoint[int]) Interval[Endpoint[int]] {
+		return Interval[Endpoint[int]]{Start: CanonicalEndpoint(d, start), End: CanonicalEndpoint(d, end)}
+	}
+	a := canonical(MakeEndpoints(1, Inclusive, 2, Inclusive))
+	b := canonical(MakeEndpoints(2, Exclusive, 5, Exclusive))
+	expect(t, a, canonical(MakeEndpoints(0, Exclusive, 3, Exclusive)))
+	expect(t, a.Adjacent(b, EndpointCompareFn(c)), true)
+	expect(t, b.End, Endpoint[int]{B: 5})
+	expect(t, canonical(Endpoint[int]{B: 3, PlusEpsilon: false}, Endpoint[int]{B: 3, PlusEpsilon: true}).End, Endpoint[int]{B: 4})
+}
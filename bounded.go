@@ -0,0 +1,97 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axisds
+
+// Bounded is a Boundary that extends a simpler boundary type with negative
+// and positive infinity, allowing intervals like [a, +inf). It can be combined
+// with Endpoint (as Endpoint[Bounded[B]]) to allow arbitrary interval types.
+type Bounded[B Boundary] struct {
+	// B is the boundary; it is only used if Inf is 0.
+	B B
+	// Inf is -1 for negative infinity, +1 for positive infinity and 0 for the
+	// finite boundary B.
+	Inf int8
+}
+
+// Finite returns the finite Bounded boundary b.
+func Finite[B Boundary](b B) Bounded[B] {
+	return Bounded[B]{B: b}
+}
+
+// NegInf returns the Bounded boundary that is before all finite boundaries.
+func NegInf[B Boundary]() Bounded[B] {
+	return Bounded[B]{Inf: -1}
+}
+
+// PosInf returns the Bounded boundary that is after all finite boundaries.
+func PosInf[B Boundary]() Bounded[B] {
+	return Bounded[B]{Inf: +1}
+}
+
+// IsFinite returns true if the boundary is not -inf or +inf.
+func (b Bounded[B]) IsFinite() bool {
+	return b.Inf == 0
+}
+
+// BoundedCompareFn returns a CompareFn for Bounded[B].
+func BoundedCompareFn[B Boundary](bCmp CompareFn[B]) CompareFn[Bounded[B]] {
+	return func(x, y Bounded[B]) int {
+		switch {
+		case x.Inf < y.Inf:
+			return -1
+		case x.Inf > y.Inf:
+			return +1
+		case x.Inf != 0:
+			return 0
+		default:
+			return bCmp(x.B, y.B)
+		}
+	}
+}
+
+// MakeBoundedFormatter creates a BoundaryFormatter[Bounded[B]] which uses the
+// given formatter for finite boundaries, and "-inf" / "+inf" for the infinite
+// boundaries.
+func MakeBoundedFormatter[B Boundary](bFmt BoundaryFormatter[B]) BoundaryFormatter[Bounded[B]] {
+	return func(b Bounded[B]) string {
+		switch b.Inf {
+		case -1:
+			return "-inf"
+		case +1:
+			return "+inf"
+		default:
+			return bFmt(b.B)
+		}
+	}
+}
+
+// MakeBoundedParser creates a Parser[Bounded[B]] which parses "-inf" and
+// "+inf" as the infinite boundaries and uses the given parser for finite
+// boundaries; it is the counterpart of MakeBoundedFormatter.
+func MakeBoundedParser[B Boundary](p Parser[B]) Parser[Bounded[B]] {
+	return MakeFuncParser(func(str string) (Bounded[B], error) {
+		switch str {
+		case "-inf":
+			return NegInf[B](), nil
+		case "+inf":
+			return PosInf[B](), nil
+		}
+		b, err := p.ParseBoundary(str)
+		if err != nil {
+			return Bounded[B]{}, err
+		}
+		return Finite(b), nil
+	})
+}
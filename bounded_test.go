@@ -0,0 +1,51 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axisds
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestBounded(t *testing.T) {
+	c := BoundedCompareFn(cmp.Compare[int])
+	values := []Bounded[int]{NegInf[int](), Finite(-100), Finite(0), Finite(100), PosInf[int]()}
+	for i := range values {
+		for j := range values {
+			expect(t, c(values[i], values[j]), cmp.Compare(i, j))
+		}
+	}
+	expect(t, NegInf[int]().IsFinite(), false)
+	expect(t, Finite(0).IsFinite(), true)
+
+	bFmt := MakeBoundedFormatter(MakeBoundaryFormatter[int]())
+	iFmt := MakeIntervalFormatter(bFmt)
+	expect(t, iFmt(Finite(1), PosInf[int]()), "[1, +inf)")
+	expect(t, iFmt(NegInf[int](), Finite(-1)), "[-inf, -1)")
+
+	p := MakeBoundedParser(MakeBasicParser[int]())
+	testParse(t, p, "[1, +inf)", Finite(1), PosInf[int](), "")
+	testParse(t, p, "[-inf, -1) foo", NegInf[int](), Finite(-1), "foo")
+	testParseErr(t, p, "[inf, 1)")
+	testRoundtrip(t, iFmt, p, NegInf[int](), PosInf[int]())
+
+	// Combined with Endpoint.
+	ep := MakeEndpointParser(MakeBoundedParser(MakeBasicParser[int]()))
+	eFmt := MakeEndpointIntervalFormatter(bFmt)
+	start, end := MustParseInterval(ep, "(-inf, 5]")
+	expect(t, start, MakeStartEndpoint(NegInf[int](), Exclusive))
+	expect(t, end, MakeEndEndpoint(Finite(5), Inclusive))
+	expect(t, eFmt(start, end), "(-inf, 5]")
+}
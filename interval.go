@@ -0,0 +1,102 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axisds
+
+// Interval is the half-open interval [Start, End). An interval with
+// Start >= End is empty.
+//
+// Intervals with arbitrary inclusive or exclusive ends can be represented as
+// Interval[Endpoint[B]] (using EndpointCompareFn).
+type Interval[B Boundary] struct {
+	Start, End B
+}
+
+// IsEmpty returns true if the interval contains nothing (Start >= End).
+func (i Interval[B]) IsEmpty(cmp CompareFn[B]) bool {
+	return cmp(i.Start, i.End) >= 0
+}
+
+// Contains returns true if Start <= b < End.
+func (i Interval[B]) Contains(b B, cmp CompareFn[B]) bool {
+	return cmp(i.Start, b) <= 0 && cmp(b, i.End) < 0
+}
+
+// Overlaps returns true if the two intervals have a non-empty intersection.
+func (i Interval[B]) Overlaps(other Interval[B], cmp CompareFn[B]) bool {
+	return !i.Intersect(other, cmp).IsEmpty(cmp)
+}
+
+// Adjacent returns true if the two intervals are non-empty and one of them
+// ends where the other one starts, e.g. [1, 3) and [3, 5). For intervals with
+// Endpoint boundaries, see CanonicalEndpoint.
+func (i Interval[B]) Adjacent(other Interval[B], cmp CompareFn[B]) bool {
+	if i.IsEmpty(cmp) || other.IsEmpty(cmp) {
+		return false
+	}
+	return cmp(i.End, other.Start) == 0 || cmp(other.End, i.Start) == 0
+}
+
+// Intersect returns the intersection of the two intervals, which can be empty.
+func (i Interval[B]) Intersect(other Interval[B], cmp CompareFn[B]) Interval[B] {
+	res := i
+	if cmp(other.Start, res.Start) > 0 {
+		res.Start = other.Start
+	}
+	if cmp(other.End, res.End) < 0 {
+		res.End = other.End
+	}
+	return res
+}
+
+// Union returns the union of the two intervals, if it is an interval (i.e. the
+// intervals overlap or are adjacent, or one of them is empty). Otherwise, ok is
+// false.
+func (i Interval[B]) Union(other Interval[B], cmp CompareFn[B]) (_ Interval[B], ok bool) {
+	switch {
+	case other.IsEmpty(cmp):
+		return i, true
+	case i.IsEmpty(cmp):
+		return other, true
+	case cmp(i.Start, other.End) > 0 || cmp(other.Start, i.End) > 0:
+		return Interval[B]{}, false
+	}
+	res := i
+	if cmp(other.Start, res.Start) < 0 {
+		res.Start = other.Start
+	}
+	if cmp(other.End, res.End) > 0 {
+		res.End = other.End
+	}
+	return res, true
+}
+
+// Subtract returns the parts of the interval that are not in other: the part
+// before other and the part after other. Either (or both) can be empty.
+func (i Interval[B]) Subtract(
+	other Interval[B], cmp CompareFn[B],
+) (before, after Interval[B]) {
+	if other.IsEmpty(cmp) {
+		// Nothing to subtract; return the entire interval as the first part.
+		return i, Interval[B]{Start: i.End, End: i.End}
+	}
+	before, after = i, i
+	if cmp(other.Start, before.End) < 0 {
+		before.End = other.Start
+	}
+	if cmp(other.End, after.Start) > 0 {
+		after.Start = other.End
+	}
+	return before, after
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axisds
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+)
+
+// TestInterval checks the interval operations against the sets of integers
+// contained in the intervals, for all small intervals.
+func TestInterval(t *testing.T) {
+	const n = 6
+	c := cmp.Compare[int]
+	// set returns a bitmap of the integers in the interval.
+	set := func(i Interval[int]) uint64 {
+		var res uint64
+		for x := 0; x < n; x++ {
+			if i.Contains(x, c) {
+				res |= 1 << x
+			}
+		}
+		return res
+	}
+	var all []Interval[int]
+	for s := 0; s < n; s++ {
+		for e := 0; e < n; e++ {
+			all = append(all, Interval[int]{Start: s, End: e})
+		}
+	}
+	for _, a := range all {
+		expect(t, a.IsEmpty(c), set(a) == 0)
+		for _, b := range all {
+			ctx := fmt.Sprintf("%v %v", a, b)
+			sa, sb := set(a), set(b)
+			if a.Overlaps(b, c) != (sa&sb != 0) {
+				t.Fatalf("%s: incorrect Overlaps", ctx)
+			}
+			if set(a.Intersect(b, c)) != sa&sb {
+				t.Fatalf("%s: incorrect Intersect", ctx)
+			}
+			if u, ok := a.Union(b, c); ok {
+				if set(u) != sa|sb {
+					t.Fatalf("%s: incorrect Union %v", ctx, u)
+				}
+			} else if sa == 0 || sb == 0 || (a.End >= b.Start && b.End >= a.Start) {
+				t.Fatalf("%s: Union not ok", ctx)
+			}
+			before, after := a.Subtract(b, c)
+			if set(before)&set(after) != 0 || set(before)|set(after) != sa&^sb {
+				t.Fatalf("%s: incorrect Subtract %v %v", ctx, before, after)
+			}
+			if !before.IsEmpty(c) && !after.IsEmpty(c) && before.End > after.Start {
+				t.Fatalf("%s: incorrect Subtract order %v %v", ctx, before, after)
+			}
+		}
+	}
+}
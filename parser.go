@@ -36,6 +36,12 @@ func MakeBasicParser[B Boundary]() Parser[B] {
 	return basicParser[B]{}
 }
 
+// MakeFuncParser creates a Parser[B] that uses the given function to parse the
+// boundaries.
+func MakeFuncParser[B Boundary](parseBoundary func(str string) (B, error)) Parser[B] {
+	return funcParser[B]{parseBoundary: parseBoundary}
+}
+
 // MakeEndpointParser creates a Parser[Endpoint[B]].
 func MakeEndpointParser[B Boundary](p Parser[B]) Parser[Endpoint[B]] {
 	return &endpointParser[B]{p: p}
@@ -75,14 +81,35 @@ func (p basicParser[B]) ParseBoundary(str string) (b B, err error) {
 }
 
 func (p basicParser[B]) ParseInterval(input string) (start, end B, remaining string, err error) {
+	return parseInterval(input, p.ParseBoundary)
+}
+
+type funcParser[B Boundary] struct {
+	parseBoundary func(str string) (B, error)
+}
+
+var _ Parser[int] = funcParser[int]{}
+
+func (p funcParser[B]) ParseBoundary(str string) (b B, err error) {
+	return p.parseBoundary(str)
+}
+
+func (p funcParser[B]) ParseInterval(input string) (start, end B, remaining string, err error) {
+	return parseInterval(input, p.parseBoundary)
+}
+
+// parseInterval parses an interval of the form `[boundary1, boundary2)`.
+func parseInterval[B Boundary](
+	input string, parseBoundary func(str string) (B, error),
+) (start, end B, remaining string, err error) {
 	re := regexp.MustCompile(`^\[([^,]+), ([^)]+)\) *(.*)$`)
 	matches := re.FindStringSubmatch(input)
 	if matches == nil {
 		return start, end, "", fmt.Errorf("malformed interval %q", input)
 	}
-	start, err = p.ParseBoundary(matches[1])
+	start, err = parseBoundary(matches[1])
 	if err == nil {
-		end, err = p.ParseBoundary(matches[2])
+		end, err = parseBoundary(matches[2])
 	}
 	if err != nil {
 		return start, end, "", err
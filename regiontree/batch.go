@@ -0,0 +1,115 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"slices"
+
+	"github.com/RaduBerinde/btreemap"
+)
+
+// RangeUpdate describes an update of the property of a range; see Update.
+type RangeUpdate[B Boundary, P Property] struct {
+	Start, End B
+	UpdateProp func(p P) P
+}
+
+// ApplyBatch applies a batch of updates. The result is the same as calling
+// Update for each update in order, but the tree is traversed only once (over
+// the range spanned by all the updates) and the resulting regions are
+// coalesced in one final pass.
+//
+// The runtime complexity is O(U log U + log N + K) plus O(log N) for each
+// boundary that changes, where U is the number of updates and K is the number
+// of regions in the range spanned by the updates.
+func (t *T[B, P]) ApplyBatch(updates []RangeUpdate[B, P]) {
+	defer t.endOp(t.startOp())
+	type event struct {
+		b       B
+		idx     int
+		isStart bool
+	}
+	events := make([]event, 0, 2*len(updates))
+	for i, u := range updates {
+		uStart, uEnd := t.aboveWatermark(t.inputBoundary(u.Start)), t.aboveWatermark(t.inputBoundary(u.End))
+		if t.cmp(uStart, uEnd) < 0 {
+			events = append(events, event{b: uStart, idx: i, isStart: true})
+			events = append(events, event{b: uEnd, idx: i, isStart: false})
+		}
+	}
+	if len(events) == 0 {
+		return
+	}
+	slices.SortFunc(events, func(a, b event) int { return t.cmp(a.b, b.b) })
+	start, end := events[0].b, events[len(events)-1].b
+
+	// active contains the indexes of the updates that apply to the current
+	// position, in increasing order.
+	var active []int
+	var regions []boundaryProp[B, P]
+	cur := start
+	_, curProp := t.endBoundaryInfo(start)
+	// addFragment adds the region [cur, fEnd), applying all active updates.
+	addFragment := func(fEnd B) {
+		if t.cmp(cur, fEnd) < 0 {
+			prop := t.normalize(curProp)
+			for _, idx := range active {
+				prop = updates[idx].UpdateProp(prop)
+			}
+			t.notifyChange(cur, fEnd, curProp, prop)
+			regions = append(regions, boundaryProp[B, P]{b: cur, prop: prop})
+			cur = fEnd
+		}
+	}
+	// nextEvent processes all the events at the next event boundary.
+	ei := 0
+	nextEvent := func() {
+		b := events[ei].b
+		addFragment(b)
+		for ; ei < len(events) && t.cmp(events[ei].b, b) == 0; ei++ {
+			i, _ := slices.BinarySearch(active, events[ei].idx)
+			if events[ei].isStart {
+				active = slices.Insert(active, i, events[ei].idx)
+			} else {
+				active = slices.Delete(active, i, i+1)
+			}
+		}
+	}
+	t.tree.AscendFunc(btreemap.GT(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		for t.cmp(events[ei].b, rStart) <= 0 {
+			nextEvent()
+		}
+		addFragment(rStart)
+		curProp = rProp
+		return true
+	})
+	for ei < len(events) {
+		nextEvent()
+	}
+	t.setRegions(start, end, regions)
+}
+
+// Apply replays the entries of an operation log (see Options.OpLog), in order:
+// the property of each entry's range is set to the entry's property. The entry
+// boundaries come from a tree, so Options.NormalizeBoundary and
+// Options.Quantize are not applied to them.
+//
+// The runtime complexity is O(K log N) where K is the number of entries.
+func (t *T[B, P]) Apply(log []Region[B, P]) {
+	defer t.endOp(t.startOp())
+	for _, e := range log {
+		t.set(e.Start, e.End, e.Prop)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestApplyBatch(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		var batch []RangeUpdate[int, int]
+		for i, numUpdates := 0, rng.IntN(20); i < numUpdates; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			// Use non-commutative updates to verify the order.
+			if rng.IntN(2) == 0 {
+				delta := rng.IntN(5) - 2
+				batch = append(batch, RangeUpdate[int, int]{Start: a, End: b, UpdateProp: func(p int) int { return p + delta }})
+				n.Add(a, b, delta)
+			} else {
+				value := rng.IntN(5) - 2
+				batch = append(batch, RangeUpdate[int, int]{Start: a, End: b, UpdateProp: func(p int) int { return value }})
+				n.Set(a, b, value)
+			}
+		}
+		rt.ApplyBatch(batch)
+		rt.CheckInvariants()
+		checkEqual(t, &rt, &n, fmt.Sprintf("seed: %d", seed))
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Marshalable wraps a region tree and implements encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler, which allows the tree to be used with gob and
+// other persistence code.
+//
+// Boundaries and properties are encoded using their MarshalBinary method, or
+// their MarshalText method if MarshalBinary is not implemented. They are
+// decoded using UnmarshalBinary or UnmarshalText, which are generally
+// implemented on *B and *P. Encoding or decoding fails if B or P implement
+// neither.
+//
+// The wrapped tree must be created with Make before UnmarshalBinary is called.
+type Marshalable[B Boundary, P Property] struct {
+	*T[B, P]
+}
+
+var _ encoding.BinaryMarshaler = Marshalable[int, int]{}
+var _ encoding.BinaryUnmarshaler = (*Marshalable[int, int])(nil)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding contains the
+// regions with non-zero property (as emitted by EnumerateAll).
+func (m Marshalable[B, P]) MarshalBinary() ([]byte, error) {
+	var buf []byte
+	var err error
+	n := 0
+	m.EnumerateAll(func(start, end B, prop P) bool {
+		n++
+		for _, v := range [...]any{start, end, prop} {
+			if buf, err = appendValue(buf, v); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(binary.AppendUvarint(nil, uint64(n)), buf...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Any existing regions
+// are removed.
+func (m *Marshalable[B, P]) UnmarshalBinary(data []byte) error {
+	if m.T == nil || m.tree == nil {
+		return errors.New("regiontree: UnmarshalBinary called on uninitialized tree")
+	}
+	n, l := binary.Uvarint(data)
+	if l <= 0 {
+		return errors.New("regiontree: invalid encoding")
+	}
+	data = data[l:]
+	m.Reset()
+	for i := uint64(0); i < n; i++ {
+		var start, end B
+		var prop P
+		var err error
+		if data, err = decodeValue(data, &start); err != nil {
+			return err
+		}
+		if data, err = decodeValue(data, &end); err != nil {
+			return err
+		}
+		if data, err = decodeValue(data, &prop); err != nil {
+			return err
+		}
+		m.Update(start, end, func(P) P { return prop })
+	}
+	if len(data) > 0 {
+		return errors.New("regiontree: invalid encoding (extra data)")
+	}
+	return nil
+}
+
+var _ encoding.BinaryMarshaler = Region[int, int]{}
+var _ encoding.BinaryUnmarshaler = (*Region[int, int])(nil)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The boundaries and the
+// property are encoded as described in Marshalable. This can be used to encode
+// the entries of an operation log (see Options.OpLog).
+func (r Region[B, P]) MarshalBinary() ([]byte, error) {
+	var buf []byte
+	var err error
+	for _, v := range [...]any{r.Start, r.End, r.Prop} {
+		if buf, err = appendValue(buf, v); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *Region[B, P]) UnmarshalBinary(data []byte) error {
+	var err error
+	for _, v := range [...]any{&r.Start, &r.End, &r.Prop} {
+		if data, err = decodeValue(data, v); err != nil {
+			return err
+		}
+	}
+	if len(data) > 0 {
+		return errors.New("regiontree: invalid encoding (extra data)")
+	}
+	return nil
+}
+
+// appendValue appends the length-prefixed encoding of v.
+func appendValue(buf []byte, v any) ([]byte, error) {
+	var enc []byte
+	var err error
+	switch v := v.(type) {
+	case encoding.BinaryMarshaler:
+		enc, err = v.MarshalBinary()
+	case encoding.TextMarshaler:
+		enc, err = v.MarshalText()
+	default:
+		return nil, fmt.Errorf("regiontree: %T does not implement BinaryMarshaler or TextMarshaler", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(enc)))
+	return append(buf, enc...), nil
+}
+
+// decodeValue decodes a length-prefixed value into v (which is a pointer) and
+// returns the remaining data.
+func decodeValue(data []byte, v any) ([]byte, error) {
+	n, l := binary.Uvarint(data)
+	if l <= 0 || uint64(len(data)-l) < n {
+		return nil, errors.New("regiontree: invalid encoding")
+	}
+	enc := data[l : l+int(n)]
+	var err error
+	switch v := v.(type) {
+	case encoding.BinaryUnmarshaler:
+		err = v.UnmarshalBinary(enc)
+	case encoding.TextUnmarshaler:
+		err = v.UnmarshalText(enc)
+	default:
+		return nil, fmt.Errorf("regiontree: %T does not implement BinaryUnmarshaler or TextUnmarshaler", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data[l+int(n):], nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+)
+
+type textProp string
+
+func (p textProp) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+func (p *textProp) UnmarshalText(data []byte) error {
+	*p = textProp(data)
+	return nil
+}
+
+func TestMarshalable(t *testing.T) {
+	makeTree := func() T[time.Time, textProp] {
+		return Make[time.Time, textProp](
+			func(a, b time.Time) int { return a.Compare(b) },
+			func(a, b textProp) bool { return a == b },
+		)
+	}
+	rt := makeTree()
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return t0.Add(time.Duration(hours) * time.Hour) }
+	rt.Update(at(1), at(5), func(textProp) textProp { return "a" })
+	rt.Update(at(3), at(8), func(textProp) textProp { return "b" })
+	rt.Update(at(10), at(12), func(textProp) textProp { return "c" })
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(Marshalable[time.Time, textProp]{&rt}); err != nil {
+		t.Fatal(err)
+	}
+	rt2 := makeTree()
+	rt2.Update(at(0), at(100), func(textProp) textProp { return "x" })
+	if err := gob.NewDecoder(&buf).Decode(&Marshalable[time.Time, textProp]{&rt2}); err != nil {
+		t.Fatal(err)
+	}
+	rt2.CheckInvariants()
+	if !rt.Equal(&rt2) {
+		t.Fatalf("roundtrip failed")
+	}
+
+	// Properties that can't be marshaled.
+	rt3 := Make[time.Time, int](func(a, b time.Time) int { return a.Compare(b) }, func(a, b int) bool { return a == b })
+	rt3.Update(at(1), at(2), func(int) int { return 1 })
+	if _, err := (Marshalable[time.Time, int]{&rt3}).MarshalBinary(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRegionMarshalBinary(t *testing.T) {
+	r := Region[textProp, textProp]{Start: "a", End: "c", Prop: "foo"}
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r2 Region[textProp, textProp]
+	if err := r2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if r2 != r {
+		t.Fatalf("roundtrip failed: %v", r2)
+	}
+	if err := r2.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import "github.com/RaduBerinde/axisds"
+
+// Builder is used to construct a region tree from a sorted stream of regions.
+// It is more efficient than a sequence of Update calls because it does not
+// need to look up the existing regions.
+type Builder[B Boundary, P Property] struct {
+	t T[B, P]
+	// lastEnd is the end boundary of the last appended region; lastProp is
+	// its property.
+	lastEnd  B
+	lastProp P
+	started  bool
+}
+
+// MakeBuilder creates a new Builder with the given boundary and property
+// comparison functions.
+func MakeBuilder[B Boundary, P Property](
+	cmp axisds.CompareFn[B], propEq PropertyEqualFn[P],
+) Builder[B, P] {
+	return Builder[B, P]{t: Make(cmp, propEq)}
+}
+
+// newBuilder returns a Builder for a tree with the same settings as t. The
+// builder uses the same time as t for Options.Deadline.
+func (t *T[B, P]) newBuilder() Builder[B, P] {
+	b := Builder[B, P]{t: t.newEmpty()}
+	b.t.now, b.t.hasNow = t.now, t.hasNow
+	return b
+}
+
+// Append adds a region [start, end) with the given property. The start
+// boundary must be greater or equal to the end boundary of the previously
+// appended region (Append panics otherwise). Empty regions are ignored.
+//
+// Consecutive regions that touch and have equal properties are merged.
+func (b *Builder[B, P]) Append(start, end B, prop P) {
+	t := &b.t
+	if t.cmp(start, end) >= 0 {
+		return
+	}
+	var zeroProp P
+	if b.started {
+		c := t.cmp(start, b.lastEnd)
+		if c < 0 {
+			panic("regions not appended in increasing order")
+		}
+		if c > 0 && !t.propEq(b.lastProp, zeroProp) {
+			// There is a gap between the last region and this one.
+			t.tree.ReplaceOrInsert(b.lastEnd, zeroProp)
+			b.lastProp = zeroProp
+		}
+	} else {
+		b.lastProp = zeroProp
+	}
+	if !t.propEq(prop, b.lastProp) {
+		t.tree.ReplaceOrInsert(start, prop)
+		b.lastProp = prop
+	}
+	b.lastEnd = end
+	b.started = true
+}
+
+// Finish returns the region tree containing all the appended regions. The
+// Builder must not be used afterward.
+func (b *Builder[B, P]) Finish() T[B, P] {
+	var zeroProp P
+	if b.started && !b.t.propEq(b.lastProp, zeroProp) {
+		b.t.tree.ReplaceOrInsert(b.lastEnd, zeroProp)
+	}
+	t := b.t
+	t.endOp(t.hasNow)
+	*b = Builder[B, P]{}
+	return t
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		b := MakeBuilder[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+		var n naiveInts
+		for pos := rng.IntN(10); ; {
+			start := pos + rng.IntN(3)
+			end := start + rng.IntN(10)
+			if end >= maxRange {
+				break
+			}
+			prop := rng.IntN(3)
+			b.Append(start, end, prop)
+			n.Set(start, end, prop)
+			pos = end
+		}
+		rt := b.Finish()
+		rt.CheckInvariants()
+		checkEqual(t, &rt, &n, fmt.Sprintf("seed: %d", seed))
+	}
+}
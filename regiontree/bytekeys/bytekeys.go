@@ -0,0 +1,125 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bytekeys contains helpers for region trees with []byte boundaries
+// (keys), ordered with bytes.Compare.
+package bytekeys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/RaduBerinde/axisds"
+	"github.com/RaduBerinde/axisds/regiontree"
+)
+
+// Compare is an axisds.CompareFn[[]byte].
+func Compare(x, y []byte) int {
+	return bytes.Compare(x, y)
+}
+
+var _ axisds.CompareFn[[]byte] = Compare
+
+// Make creates a new region tree with []byte boundaries.
+//
+// The tree retains the boundaries passed to it; they must not be modified
+// afterwards.
+func Make[P regiontree.Property](propEq regiontree.PropertyEqualFn[P]) regiontree.T[[]byte, P] {
+	return regiontree.Make[[]byte, P](Compare, propEq)
+}
+
+// PrefixEnd returns the smallest key that is larger than all the keys with the
+// given prefix. If there is no such key (the prefix is empty or consists only
+// of 0xff bytes), ok is false.
+//
+// For example, the end of "ab\xff" is "ac" (not "ab\x00" or "ac\x00").
+func PrefixEnd(prefix []byte) (end []byte, ok bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			end = make([]byte, i+1)
+			copy(end, prefix)
+			end[i]++
+			return end, true
+		}
+	}
+	return nil, false
+}
+
+// PrefixToInterval returns the interval [prefix, PrefixEnd(prefix)) which
+// contains exactly the keys with the given prefix. If the prefix has no end
+// (see PrefixEnd), ok is false.
+//
+// The start of the interval is the prefix slice itself; the end is a new
+// slice.
+func PrefixToInterval(prefix []byte) (_ axisds.Interval[[]byte], ok bool) {
+	end, ok := PrefixEnd(prefix)
+	if !ok {
+		return axisds.Interval[[]byte]{}, false
+	}
+	return axisds.Interval[[]byte]{Start: prefix, End: end}, true
+}
+
+// MakeHexFormatter creates a BoundaryFormatter[[]byte] that formats keys as
+// uppercase hex strings with a 0x prefix, e.g. 0xAABB.
+func MakeHexFormatter() axisds.BoundaryFormatter[[]byte] {
+	return formatHex
+}
+
+// MakeHexIntervalFormatter creates an IntervalFormatter[[]byte] that formats
+// the keys like MakeHexFormatter, e.g. [0xAABB, 0xAACC).
+//
+// If elideSharedPrefix is set, the bytes that the end key shares with the
+// start key are replaced with "{n}..", where n is the number of elided bytes,
+// e.g. [0xAABB, 0x{1}..CC) or [0xAABB, 0x{2}..CC) (for the end key 0xAABBCC).
+func MakeHexIntervalFormatter(elideSharedPrefix bool) axisds.IntervalFormatter[[]byte] {
+	return func(start, end []byte) string {
+		if elideSharedPrefix {
+			n := 0
+			for n < len(start) && n < len(end) && start[n] == end[n] {
+				n++
+			}
+			if n > 0 && n < len(end) {
+				return fmt.Sprintf("[%s, 0x{%d}..%s)", formatHex(start), n, strings.ToUpper(hex.EncodeToString(end[n:])))
+			}
+		}
+		return fmt.Sprintf("[%s, %s)", formatHex(start), formatHex(end))
+	}
+}
+
+func formatHex(b []byte) string {
+	return "0x" + strings.ToUpper(hex.EncodeToString(b))
+}
+
+// MakeEscapedFormatter creates a BoundaryFormatter[[]byte] that formats keys
+// as strings, with non-printable ASCII characters (and all non-ASCII bytes)
+// escaped as \xNN; backslashes are escaped as \\. Commas, parentheses and
+// brackets are also escaped, so the result can be used in intervals.
+func MakeEscapedFormatter() axisds.BoundaryFormatter[[]byte] {
+	return func(b []byte) string {
+		var sb strings.Builder
+		for _, c := range b {
+			switch {
+			case c == '\\':
+				sb.WriteString(`\\`)
+			case c < 0x20 || c >= 0x7f || c == ',' || c == '(' || c == ')' || c == '[' || c == ']':
+				fmt.Fprintf(&sb, `\x%02x`, c)
+			default:
+				sb.WriteByte(c)
+			}
+		}
+		return sb.String()
+	}
+}
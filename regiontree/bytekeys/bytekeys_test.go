@@ -0,0 +1,98 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bytekeys
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/RaduBerinde/axisds"
+)
+
+func TestPrefixEnd(t *testing.T) {
+	hexFmt := MakeHexFormatter()
+	for _, tc := range []struct {
+		prefix string
+		end    string
+	}{
+		{"", ""},
+		{"\xff", ""},
+		{"\xff\xff", ""},
+		{"a", "b"},
+		{"ab\xff", "ac"},
+		{"a\xff\xff", "b"},
+		{"a\x00", "a\x01"},
+		{"\xfe\xff", "\xff"},
+	} {
+		end, ok := PrefixEnd([]byte(tc.prefix))
+		if ok != (tc.end != "") || string(end) != tc.end {
+			t.Errorf("PrefixEnd(%s) = %s (ok=%t)", hexFmt([]byte(tc.prefix)), hexFmt(end), ok)
+		}
+	}
+
+	// Check PrefixToInterval against random keys.
+	for test := 0; test < 1000; test++ {
+		randKey := func() []byte {
+			k := make([]byte, rand.IntN(4))
+			for i := range k {
+				k[i] = []byte{0, 1, 0xfe, 0xff}[rand.IntN(4)]
+			}
+			return k
+		}
+		prefix, key := randKey(), randKey()
+		i, ok := PrefixToInterval(prefix)
+		if !ok {
+			continue
+		}
+		if i.Contains(key, Compare) != bytes.HasPrefix(key, prefix) {
+			t.Fatalf("incorrect interval %s for %s (key %s)",
+				axisds.MakeIntervalFormatter(hexFmt)(i.Start, i.End), hexFmt(prefix), hexFmt(key))
+		}
+	}
+}
+
+func TestFormatters(t *testing.T) {
+	k := []byte("a,b\\c\x00\xff")
+	if res := MakeHexFormatter()(k); res != "0x612C625C6300FF" {
+		t.Errorf("incorrect hex formatting %q", res)
+	}
+	for _, tc := range []struct {
+		start, end string
+		elide      bool
+		expected   string
+	}{
+		{"\xaa\xbb", "\xaa\xcc", false, "[0xAABB, 0xAACC)"},
+		{"\xaa\xbb", "\xaa\xcc", true, "[0xAABB, 0x{1}..CC)"},
+		{"\xaa\xbb", "\xaa\xbb\xcc", true, "[0xAABB, 0x{2}..CC)"},
+		{"\xaa\xbb", "\xbb", true, "[0xAABB, 0xBB)"},
+		{"\xaa", "\xaa\x00", true, "[0xAA, 0x{1}..00)"},
+		{"", "\x01", true, "[0x, 0x01)"},
+	} {
+		if res := MakeHexIntervalFormatter(tc.elide)([]byte(tc.start), []byte(tc.end)); res != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, res)
+		}
+	}
+	if res := MakeEscapedFormatter()(k); res != `a\x2cb\\c\x00\xff` {
+		t.Errorf("incorrect escaped formatting %q", res)
+	}
+
+	rt := Make[int](func(a, b int) bool { return a == b })
+	i, _ := PrefixToInterval([]byte("foo"))
+	rt.Set(i.Start, i.End, 1)
+	if res := rt.String(axisds.MakeIntervalFormatter(MakeEscapedFormatter())); res != "[foo, fop) = 1\n" {
+		t.Errorf("incorrect tree:\n%s", res)
+	}
+}
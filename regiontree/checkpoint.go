@@ -0,0 +1,156 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import "github.com/RaduBerinde/btreemap"
+
+// undoLog records the information necessary to undo modifications since the
+// active checkpoints.
+type undoLog[B Boundary, P Property] struct {
+	entries []undoEntry[B, P]
+	// marks contains the length of entries when each active checkpoint was
+	// created.
+	marks []int
+}
+
+// undoEntry records the state before a modification: either the regions in a
+// range (when snapshot is nil), or a snapshot of the entire tree.
+type undoEntry[B Boundary, P Property] struct {
+	end B
+	// regions starts at the start of the range; the last one ends at end.
+	regions  []boundaryProp[B, P]
+	snapshot *btreemap.BTreeMap[B, P]
+	// lowWatermark and hasLowWatermark are the low watermark of the tree at
+	// the time of the snapshot (see SetLowWatermark).
+	lowWatermark    B
+	hasLowWatermark bool
+}
+
+// Checkpoint starts recording an undo log, so that all modifications after
+// this point can be undone with Rollback. The checkpoint must be ended with
+// either Rollback or Release. Checkpoints can be nested.
+//
+// While a checkpoint is active, each modification records the previous state
+// of the range that it touches, so the overhead is proportional to the size of
+// the modification. Modifications of the entire tree (Reset, Truncate, Shift,
+// and coarsening) record a lazy clone of the tree instead.
+func (t *T[B, P]) Checkpoint() {
+	if t.undo == nil {
+		t.undo = &undoLog[B, P]{}
+	}
+	t.undo.marks = append(t.undo.marks, len(t.undo.entries))
+}
+
+// Rollback undoes all modifications since the most recent checkpoint and ends
+// the checkpoint. The OnChange hook (if set) is invoked for the restored
+// ranges.
+//
+// The runtime complexity is O(K log N) where K is the number of regions in all
+// the ranges modified since the checkpoint.
+func (t *T[B, P]) Rollback() {
+	defer t.endOp(t.startOp())
+	u, mark := t.popCheckpoint()
+	// Don't record the undo operations, and don't coarsen while we restore the
+	// previous state.
+	t.undo = nil
+	maxBoundaries := t.opts.MaxBoundaries
+	t.opts.MaxBoundaries = 0
+	for i := len(u.entries) - 1; i >= mark; i-- {
+		e := &u.entries[i]
+		if e.snapshot != nil {
+			t.restoreSnapshot(e.snapshot)
+			t.lowWatermark, t.hasLowWatermark = e.lowWatermark, e.hasLowWatermark
+			continue
+		}
+		for j, r := range e.regions {
+			end := e.end
+			if j+1 < len(e.regions) {
+				end = e.regions[j+1].b
+			}
+			t.set(r.b, end, r.prop)
+		}
+	}
+	t.opts.MaxBoundaries = maxBoundaries
+	u.entries = u.entries[:mark]
+	if len(u.marks) > 0 {
+		t.undo = u
+	}
+}
+
+// Release ends the most recent checkpoint, keeping all modifications. If there
+// is an outer checkpoint, the modifications can still be undone by rolling it
+// back.
+func (t *T[B, P]) Release() {
+	u, _ := t.popCheckpoint()
+	if len(u.marks) > 0 {
+		t.undo = u
+	} else {
+		t.undo = nil
+	}
+}
+
+// popCheckpoint removes the most recent checkpoint mark and returns the undo
+// log along with the removed mark.
+func (t *T[B, P]) popCheckpoint() (u *undoLog[B, P], mark int) {
+	u = t.undo
+	if u == nil || len(u.marks) == 0 {
+		panic("no active checkpoint")
+	}
+	mark = u.marks[len(u.marks)-1]
+	u.marks = u.marks[:len(u.marks)-1]
+	return u, mark
+}
+
+// recordRange records the regions in [start, end) in the undo log, if there is
+// an active checkpoint. Must be called before modifying the range.
+func (t *T[B, P]) recordRange(start, end B) {
+	if t.undo == nil {
+		return
+	}
+	_, prop := t.endBoundaryInfo(start)
+	regions := []boundaryProp[B, P]{{b: start, prop: prop}}
+	t.tree.AscendFunc(btreemap.GT(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		regions = append(regions, boundaryProp[B, P]{b: rStart, prop: rProp})
+		return true
+	})
+	t.undo.entries = append(t.undo.entries, undoEntry[B, P]{end: end, regions: regions})
+}
+
+// recordSnapshot records a lazy clone of the tree in the undo log, if there is
+// an active checkpoint. Must be called before modifying the tree.
+func (t *T[B, P]) recordSnapshot() {
+	if t.undo == nil {
+		return
+	}
+	t.undo.entries = append(t.undo.entries, undoEntry[B, P]{
+		snapshot:        t.tree.Clone(),
+		lowWatermark:    t.lowWatermark,
+		hasLowWatermark: t.hasLowWatermark,
+	})
+}
+
+// restoreSnapshot replaces the internal tree with the given snapshot.
+func (t *T[B, P]) restoreSnapshot(snapshot *btreemap.BTreeMap[B, P]) {
+	defer t.endOp(t.startOp())
+	if t.opts.OnChange != nil {
+		s := *t
+		s.tree = snapshot
+		t.Diff(&s, func(start, end B, oldProp, newProp P) bool {
+			t.opts.OnChange(start, end, t.normalize(oldProp), newProp)
+			return true
+		})
+	}
+	t.tree = snapshot
+}
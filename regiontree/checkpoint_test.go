@@ -0,0 +1,124 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// TestCheckpoint runs random operations interleaved with nested checkpoints,
+// verifying that Rollback restores the state at the checkpoint. The expected
+// state is maintained through the OnChange hook, which also verifies that
+// Rollback notifies all the changes.
+func TestCheckpoint(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		var mirror naiveInts
+		var log []string
+		opts := Options[int, int]{
+			OnChange: func(start, end int, oldProp, newProp int) {
+				log = append(log, fmt.Sprintf("[%d, %d) %d -> %d", start, end, oldProp, newProp))
+				for i := start; i < end; i++ {
+					if mirror.values[i] != oldProp {
+						t.Fatalf("incorrect old property at %d; seed: %d\n%v", i, seed, log)
+					}
+					mirror.values[i] = newProp
+				}
+			},
+		}
+		if rng.IntN(2) == 0 {
+			opts.MaxBoundaries = rng.IntN(20) + 2
+			opts.MergeFn = maxMerge
+		}
+		rt := MakeWithOptions[int, int](cmp.Compare[int], func(a, b int) bool { return a == b }, opts)
+		// saved contains the expected state at each active checkpoint.
+		var saved []naiveInts
+		for i, numOps := 0, rng.IntN(100); i < numOps; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			value := rng.IntN(5)
+			switch rng.IntN(10) {
+			case 0, 1:
+				log = append(log, "checkpoint")
+				rt.Checkpoint()
+				saved = append(saved, mirror)
+			case 2:
+				if len(saved) > 0 {
+					log = append(log, "rollback")
+					rt.Rollback()
+					checkEqual(t, &rt, &saved[len(saved)-1], fmt.Sprintf("rollback\nseed: %d\n%v", seed, log))
+					saved = saved[:len(saved)-1]
+				}
+			case 3:
+				if len(saved) > 0 {
+					log = append(log, "release")
+					rt.Release()
+					saved = saved[:len(saved)-1]
+				}
+			case 4:
+				rt.Update(a, b, func(p int) int { return p + value - 2 })
+			case 5:
+				rt.Set(a, b, value)
+			case 6:
+				rt.Delete(a, b)
+			case 7:
+				rt.ApplyBatch([]RangeUpdate[int, int]{
+					{Start: a, End: b, UpdateProp: func(p int) int { return p + 1 }},
+					{Start: rng.IntN(valRange), End: valRange, UpdateProp: func(p int) int { return value }},
+				})
+			case 8:
+				switch rng.IntN(3) {
+				case 0:
+					rt.Reset()
+				case 1:
+					rt.Truncate(a, b)
+				case 2:
+					other, _ := randomTree(rng, valRange)
+					rt.Subtract(&other)
+				}
+			case 9:
+				rt.Coarsen(float64(value), intMeasure, func(a, b int) int { return max(a, b) })
+			}
+			rt.CheckInvariants()
+			checkEqual(t, &rt, &mirror, fmt.Sprintf("seed: %d\n%v", seed, log))
+		}
+		for len(saved) > 0 {
+			rt.Rollback()
+			checkEqual(t, &rt, &saved[len(saved)-1], fmt.Sprintf("final rollback\nseed: %d\n%v", seed, log))
+			saved = saved[:len(saved)-1]
+		}
+		checkEqual(t, &rt, &mirror, fmt.Sprintf("seed: %d\n%v", seed, log))
+	}
+}
+
+func TestCheckpointPanics(t *testing.T) {
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	rt.Checkpoint()
+	rt.Set(1, 10, 1)
+	rt.Release()
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	rt.Rollback()
+}
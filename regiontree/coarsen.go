@@ -0,0 +1,180 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/RaduBerinde/btreemap"
+)
+
+// MergeFn is used to coarsen a tree (see Options.MaxBoundaries). It returns the
+// property of the region [start, end) obtained by merging the adjacent regions
+// [start, mid) with property p1 and [mid, end) with property p2, along with the
+// cost of the merge (e.g. a measure of the information that is lost). Merges
+// with lower cost are performed first.
+//
+// Either property can be zero (in which case the merge extends a region over a
+// gap, or shrinks it).
+type MergeFn[B Boundary, P Property] func(start, mid, end B, p1, p2 P) (merged P, cost float64)
+
+// maybeCoarsen coarsens the tree if it has more than Options.MaxBoundaries
+// boundaries. The tree is coarsened down to 3/4 of the limit, so that the cost
+// of coarsening is amortized over multiple modifications.
+func (t *T[B, P]) maybeCoarsen() {
+	if limit := t.opts.MaxBoundaries; limit > 0 && t.tree.Len() > limit {
+		t.coarsen(max(limit*3/4, 2), math.Inf(+1), t.opts.MergeFn)
+	}
+}
+
+// Coarsen approximates the tree by merging regions with measure smaller than
+// minMeasure into one of their neighbors, using the merge function to obtain
+// the merged property (a is the property of the region on the left). The
+// smallest regions are merged first. This applies to the gaps between regions
+// as well (their property is zero), but not to the infinite regions before the
+// first region and after the last region.
+//
+// After Coarsen, every region (including gaps between regions) has measure at
+// least minMeasure, unless it is the only finite region.
+//
+// The runtime complexity is O(N log N).
+func (t *T[B, P]) Coarsen(minMeasure float64, measure MeasureFn[B], merge func(a, b P) P) {
+	defer t.endOp(t.startOp())
+	t.coarsen(2, minMeasure, func(start, mid, end B, p1, p2 P) (P, float64) {
+		return merge(p1, p2), min(measure(start, mid), measure(mid, end))
+	})
+}
+
+// coarsen merges adjacent regions (in increasing order of merge cost) until
+// the tree has at most target boundaries, or until the lowest merge cost is at
+// least maxCost (or there is nothing left to merge).
+//
+// The runtime complexity is O(N log N).
+func (t *T[B, P]) coarsen(target int, maxCost float64, mergeFn MergeFn[B, P]) {
+	if t.tree.Len() <= target {
+		return
+	}
+	t.recordSnapshot()
+	// The boundaries are organized as a doubly-linked list. The gen field is
+	// incremented whenever a node's property or next node changes or when the
+	// node is removed; it is used to invalidate heap entries.
+	type node struct {
+		b          B
+		prop       P
+		prev, next int
+		gen        int
+	}
+	nodes := make([]node, 0, t.tree.Len())
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		nodes = append(nodes, node{b: rStart, prop: rProp, prev: len(nodes) - 1, next: len(nodes) + 1})
+		return true
+	})
+	nodes[len(nodes)-1].next = -1
+
+	var h mergeHeap[P]
+	// pushPair adds a heap entry for merging the region starting at node i
+	// with the following region.
+	pushPair := func(i int) {
+		if i < 0 {
+			return
+		}
+		j := nodes[i].next
+		// The region starting at the last boundary is infinite; we can't merge
+		// it.
+		if j < 0 || nodes[j].next < 0 {
+			return
+		}
+		merged, cost := mergeFn(nodes[i].b, nodes[j].b, nodes[nodes[j].next].b, nodes[i].prop, nodes[j].prop)
+		heap.Push(&h, mergeEntry[P]{
+			cost:     cost,
+			left:     i,
+			leftGen:  nodes[i].gen,
+			rightGen: nodes[j].gen,
+			merged:   merged,
+		})
+	}
+	for i := range nodes {
+		pushPair(i)
+	}
+	for n := len(nodes); n > target && h.Len() > 0; {
+		e := heap.Pop(&h).(mergeEntry[P])
+		i := e.left
+		j := nodes[i].next
+		if nodes[i].gen != e.leftGen || j < 0 || nodes[j].gen != e.rightGen {
+			// Stale entry.
+			continue
+		}
+		if e.cost >= maxCost {
+			break
+		}
+		k := nodes[j].next
+		t.notifyChange(nodes[i].b, nodes[j].b, nodes[i].prop, e.merged)
+		t.notifyChange(nodes[j].b, nodes[k].b, nodes[j].prop, e.merged)
+		nodes[i].prop = e.merged
+		nodes[i].next = k
+		nodes[i].gen++
+		nodes[k].prev = i
+		// Node j is removed; invalidate any entries that refer to it.
+		nodes[j].gen++
+		n--
+		pushPair(nodes[i].prev)
+		pushPair(i)
+	}
+
+	// Rebuild the tree, omitting any boundaries that became unnecessary.
+	newTree := t.newBTree()
+	var lastProp P
+	for i := 0; i >= 0; i = nodes[i].next {
+		if !t.propEq(nodes[i].prop, lastProp) {
+			newTree.ReplaceOrInsert(nodes[i].b, nodes[i].prop)
+			lastProp = nodes[i].prop
+		}
+	}
+	t.tree = newTree
+}
+
+type mergeEntry[P Property] struct {
+	cost              float64
+	left              int
+	leftGen, rightGen int
+	merged            P
+}
+
+// mergeHeap is a min-heap of merge entries, ordered by cost (and then by
+// position, for determinism).
+type mergeHeap[P Property] []mergeEntry[P]
+
+var _ heap.Interface = (*mergeHeap[int])(nil)
+
+func (h mergeHeap[P]) Len() int { return len(h) }
+
+func (h mergeHeap[P]) Less(i, j int) bool {
+	if h[i].cost != h[j].cost {
+		return h[i].cost < h[j].cost
+	}
+	return h[i].left < h[j].left
+}
+
+func (h mergeHeap[P]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *mergeHeap[P]) Push(x any) { *h = append(*h, x.(mergeEntry[P])) }
+
+func (h *mergeHeap[P]) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
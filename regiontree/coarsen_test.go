@@ -0,0 +1,115 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/RaduBerinde/btreemap"
+)
+
+// maxMerge merges regions by taking the maximum property, preferring to merge
+// short regions.
+func maxMerge(start, mid, end int, p1, p2 int) (merged int, cost float64) {
+	return max(p1, p2), float64(min(mid-start, end-mid))
+}
+
+func TestMaxBoundaries(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		maxBoundaries := 2 + rng.IntN(20)
+
+		// mirror is maintained through the OnChange hook (which is also invoked
+		// by the coarsening); exact is the result without coarsening.
+		var mirror, exact naiveInts
+		rt := MakeWithOptions[int, int](cmp.Compare[int], func(a, b int) bool { return a == b }, Options[int, int]{
+			MaxBoundaries: maxBoundaries,
+			MergeFn:       maxMerge,
+			OnChange: func(start, end int, oldProp, newProp int) {
+				for i := start; i < end; i++ {
+					mirror.values[i] = newProp
+				}
+			},
+			Validate: true,
+		})
+		for op := 0; op < 50; op++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			value := rng.IntN(5)
+			if rng.IntN(4) == 0 {
+				rt.Delete(a, b)
+				exact.Set(a, b, 0)
+			} else {
+				rt.Set(a, b, value)
+				exact.Set(a, b, value)
+			}
+			context := fmt.Sprintf("max boundaries: %d\nseed: %d", maxBoundaries, seed)
+			rt.CheckInvariants()
+			if n := rt.InternalLen(); n > maxBoundaries {
+				t.Fatalf("%d boundaries\n%s", n, context)
+			}
+			checkEqual(t, &rt, &mirror, context)
+			// Since we merge using max, the coarsened tree must cover the exact
+			// tree.
+			for i := range exact.values {
+				if mirror.values[i] < exact.values[i] {
+					t.Fatalf("coarsened value %d at %d is smaller than %d\n%s", mirror.values[i], i, exact.values[i], context)
+				}
+			}
+		}
+	}
+}
+
+func TestCoarsen(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		minMeasure := float64(1 + rng.IntN(10))
+		context := fmt.Sprintf("min measure: %v\nseed: %d", minMeasure, seed)
+
+		before := rt.String(intervalFmt)
+		rt.Coarsen(minMeasure, intMeasure, func(a, b int) int { return max(a, b) })
+		rt.CheckInvariants()
+		context = fmt.Sprintf("before:\n%safter:\n%s%s", before, rt.String(intervalFmt), context)
+
+		var boundaries []int
+		rt.tree.AscendFunc(btreemap.Min[int](), btreemap.Max[int](), func(b int, _ int) bool {
+			boundaries = append(boundaries, b)
+			return true
+		})
+		if len(boundaries) > 2 {
+			for i := 1; i < len(boundaries); i++ {
+				if m := intMeasure(boundaries[i-1], boundaries[i]); m < minMeasure {
+					t.Fatalf("region [%d, %d) too small\n%s", boundaries[i-1], boundaries[i], context)
+				}
+			}
+		}
+		// Since we merge using max, the coarsened tree must cover the original.
+		for i := range n.values {
+			if p, _ := rt.RangeProperty(i, i+1); p < n.values[i] {
+				t.Fatalf("coarsened value %d at %d is smaller than %d\n%s", p, i, n.values[i], context)
+			}
+		}
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"iter"
+
+	"github.com/RaduBerinde/btreemap"
+)
+
+// Subtract sets the property to zero for all ranges where other has a
+// non-zero property.
+//
+// The two trees are iterated together in a single pass; the runtime
+// complexity is O(N + M) plus O(log N) for each boundary that changes.
+func (t *T[B, P]) Subtract(other *T[B, P]) {
+	defer t.endOp(t.startOp())
+	other = other.atNow()
+	start, end, ok := other.Bounds()
+	if !ok {
+		return
+	}
+	var zeroProp P
+	var regions []boundaryProp[B, P]
+	zip(t, other, start, end, func(fStart, fEnd B, p1, p2 P) bool {
+		if !other.propEq(p2, zeroProp) {
+			t.notifyChange(fStart, fEnd, p1, zeroProp)
+			p1 = zeroProp
+		}
+		regions = append(regions, boundaryProp[B, P]{b: fStart, prop: p1})
+		return true
+	})
+	t.setRegions(start, end, regions)
+}
+
+// Clip sets the property to zero for all ranges where keep has a zero
+// property, i.e. it keeps only the parts of the regions that are covered by
+// regions of keep (which can have any property type). For example, a set of
+// intervals to keep can be represented as a T[B, bool].
+//
+// The two trees are iterated together in a single pass; the runtime
+// complexity is O(N + M) plus O(log N) for each boundary that changes.
+func Clip[B Boundary, P, M Property](t *T[B, P], keep *T[B, M]) {
+	defer t.endOp(t.startOp())
+	keep = keep.atNow()
+	start, end, ok := t.Bounds()
+	if !ok {
+		return
+	}
+	var zeroProp P
+	var zeroKeep M
+	var regions []boundaryProp[B, P]
+	zip(t, keep, start, end, func(fStart, fEnd B, p P, k M) bool {
+		if keep.propEq(k, zeroKeep) {
+			t.notifyChange(fStart, fEnd, p, zeroProp)
+			p = zeroProp
+		}
+		regions = append(regions, boundaryProp[B, P]{b: fStart, prop: p})
+		return true
+	})
+	t.setRegions(start, end, regions)
+}
+
+// UpdateMasked is a variant of T.Update which only updates the parts of the
+// range where the mask tree has a non-zero property. The tree and the mask are
+// iterated together in a single pass; the updateProp function is called for
+// each fragment delimited by the boundaries of both trees.
+//
+// Returns true if the property of any part of the range has changed.
+//
+// The runtime complexity is O(log N + log M + K) plus O(log N) for each
+// boundary that changes, where K is the total number of regions of the two
+// trees in the range.
+func UpdateMasked[B Boundary, P, M Property](
+	t *T[B, P], mask *T[B, M], start, end B, updateProp func(p P) P,
+) (changed bool) {
+	defer t.endOp(t.startOp())
+	mask = mask.atNow()
+	start, end = t.aboveWatermark(t.inputBoundary(start)), t.aboveWatermark(t.inputBoundary(end))
+	if t.cmp(start, end) >= 0 {
+		return false
+	}
+	var zeroMask M
+	var regions []boundaryProp[B, P]
+	zip(t, mask, start, end, func(fStart, fEnd B, p P, m M) bool {
+		if !mask.propEq(m, zeroMask) {
+			newProp := updateProp(p)
+			if !t.propEq(p, newProp) {
+				changed = true
+				t.notifyChange(fStart, fEnd, p, newProp)
+			}
+			p = newProp
+		}
+		regions = append(regions, boundaryProp[B, P]{b: fStart, prop: p})
+		return true
+	})
+	t.setRegions(start, end, regions)
+	return changed
+}
+
+// Complement returns a new tree which contains the ranges within [start, end)
+// which are not covered by any region with non-zero property in t; these
+// ranges have the given property. For boolean-like properties, prop would be
+// the "true" value.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) Complement(start, end B, prop P) T[B, P] {
+	t = t.atNow()
+	start, end = t.inputBoundary(start), t.inputBoundary(end)
+	b := t.newBuilder()
+	t.EnumerateGaps(start, end, func(gapStart, gapEnd B) bool {
+		b.Append(gapStart, gapEnd, prop)
+		return true
+	})
+	return b.Finish()
+}
+
+// Equal returns true if the two trees contain the same regions with non-zero
+// property, with equal properties. The internal structure of the trees (e.g.
+// unnecessary boundaries) does not matter. Properties are compared using the
+// receiver's PropertyEqualFn.
+//
+// The runtime complexity is O(N + M).
+func (t *T[B, P]) Equal(other *T[B, P]) bool {
+	t, other = t.atNow(), other.atNow()
+	start1, end1, ok1 := t.Bounds()
+	start2, end2, ok2 := other.Bounds()
+	if !ok1 || !ok2 {
+		return ok1 == ok2
+	}
+	if t.cmp(start1, start2) != 0 || t.cmp(end1, end2) != 0 {
+		return false
+	}
+	equal := true
+	zip(t, other, start1, end1, func(fStart, fEnd B, p1, p2 P) bool {
+		equal = t.equivalent(p1, p2)
+		return equal
+	})
+	return equal
+}
+
+// Diff emits the ranges where the two trees differ, along with the property in
+// the receiver (oldProp) and the property in the other tree (newProp). Ranges
+// where both properties are zero are not emitted. Emitted ranges are in
+// increasing order; consecutive ranges with the same pair of properties are
+// merged. Properties are compared using the receiver's PropertyEqualFn.
+//
+// Diff stops once emit() returns false.
+//
+// The runtime complexity is O(N + M).
+func (t *T[B, P]) Diff(other *T[B, P], emit func(start, end B, oldProp, newProp P) bool) {
+	t, other = t.atNow(), other.atNow()
+	start1, end1, ok1 := t.Bounds()
+	start2, end2, ok2 := other.Bounds()
+	if !ok1 && !ok2 {
+		return
+	}
+	var start, end B
+	switch {
+	case !ok1:
+		start, end = start2, end2
+	case !ok2:
+		start, end = start1, end1
+	default:
+		start = minBoundary(t.cmp, start1, start2)
+		end = maxBoundary(t.cmp, end1, end2)
+	}
+
+	var cur struct {
+		start, end       B
+		oldProp, newProp P
+		set              bool
+	}
+	stopped := false
+	zip(t, other, start, end, func(fStart, fEnd B, p1, p2 P) bool {
+		if cur.set {
+			if t.equivalent(cur.oldProp, p1) && t.equivalent(cur.newProp, p2) {
+				cur.end = fEnd
+				return true
+			}
+			if !emit(cur.start, cur.end, cur.oldProp, cur.newProp) {
+				stopped = true
+				return false
+			}
+			cur.set = false
+		}
+		if !t.equivalent(p1, p2) {
+			cur.start, cur.end, cur.oldProp, cur.newProp = fStart, fEnd, p1, p2
+			cur.set = true
+		}
+		return true
+	})
+	if cur.set && !stopped {
+		emit(cur.start, cur.end, cur.oldProp, cur.newProp)
+	}
+}
+
+// ZipEnumerate iterates through two trees (with the same boundary type) in
+// lockstep, emitting the fragments of [start, end) delimited by the boundaries
+// of both trees, along with the property of each tree in that fragment.
+// Fragments where both properties are zero are not emitted; consecutive
+// fragments where both properties are equal (according to the respective
+// PropertyEqualFn) are merged.
+//
+// ZipEnumerate stops once emit() returns false.
+//
+// The runtime complexity is O(log N + log M + K) where K is the total number of
+// regions of the two trees in the range.
+func ZipEnumerate[B Boundary, P1, P2 Property](
+	t1 *T[B, P1], t2 *T[B, P2], start, end B, emit func(start, end B, p1 P1, p2 P2) bool,
+) {
+	t1, t2 = t1.atNow(), t2.atNow()
+	var zeroProp1 P1
+	var zeroProp2 P2
+	var cur struct {
+		start, end B
+		p1         P1
+		p2         P2
+		set        bool
+	}
+	stopped := false
+	zip(t1, t2, start, end, func(fStart, fEnd B, p1 P1, p2 P2) bool {
+		if cur.set {
+			if t1.propEq(cur.p1, p1) && t2.propEq(cur.p2, p2) {
+				cur.end = fEnd
+				return true
+			}
+			if !emit(cur.start, cur.end, cur.p1, cur.p2) {
+				stopped = true
+				return false
+			}
+			cur.set = false
+		}
+		if !t1.propEq(p1, zeroProp1) || !t2.propEq(p2, zeroProp2) {
+			cur.start, cur.end, cur.p1, cur.p2 = fStart, fEnd, p1, p2
+			cur.set = true
+		}
+		return true
+	})
+	if cur.set && !stopped {
+		emit(cur.start, cur.end, cur.p1, cur.p2)
+	}
+}
+
+// ZipEnumerateN is a generalization of ZipEnumerate to any number of trees
+// (with the same boundary comparison function): it emits the fragments of
+// [start, end) delimited by the boundaries of all trees, along with the
+// property of each tree in that fragment (props[i] is the property of
+// trees[i]). Fragments where all properties are zero are not emitted;
+// consecutive fragments with equal properties are merged.
+//
+// The props slice is reused between emit calls; it must not be retained or
+// modified.
+//
+// ZipEnumerateN stops once emit() returns false.
+//
+// The runtime complexity is O(T log N + K T) where T is the number of trees
+// and K is the total number of boundaries (across all trees) in the range.
+func ZipEnumerateN[B Boundary, P Property](
+	trees []*T[B, P], start, end B, emit func(start, end B, props []P) bool,
+) {
+	if len(trees) == 0 || trees[0].cmp(start, end) >= 0 {
+		return
+	}
+	trees = treesAtNow(trees)
+	cmp := trees[0].cmp
+	var zeroProp P
+
+	type treeIter struct {
+		prop  P
+		next  func() (B, P, bool)
+		nextB B
+		nextP P
+		ok    bool
+	}
+	iters := make([]treeIter, len(trees))
+	for i, t := range trees {
+		it := &iters[i]
+		_, it.prop = t.endBoundaryInfo(start)
+		var stop func()
+		it.next, stop = iter.Pull2(t.tree.Ascend(btreemap.GT(start), btreemap.LT(end)))
+		defer stop()
+		it.nextB, it.nextP, it.ok = it.next()
+	}
+	// curProps contains the properties of the pending fragment [cur, ...).
+	curProps := make([]P, len(trees))
+	for i := range iters {
+		curProps[i] = trees[i].normalize(iters[i].prop)
+	}
+	// emitCur emits the pending fragment (unless all properties are zero).
+	emitCur := func(cur, fEnd B) bool {
+		for i, p := range curProps {
+			if !trees[i].propEq(p, zeroProp) {
+				return emit(cur, fEnd, curProps)
+			}
+		}
+		return true
+	}
+	for cur := start; ; {
+		// Find the next boundary in any of the trees.
+		var fEnd B
+		found := false
+		for i := range iters {
+			if iters[i].ok && (!found || cmp(iters[i].nextB, fEnd) < 0) {
+				fEnd = iters[i].nextB
+				found = true
+			}
+		}
+		if !found {
+			emitCur(cur, end)
+			return
+		}
+		changed := false
+		for i := range iters {
+			if it := &iters[i]; it.ok && cmp(it.nextB, fEnd) == 0 {
+				it.prop = it.nextP
+				it.nextB, it.nextP, it.ok = it.next()
+				changed = changed || !trees[i].propEq(it.prop, curProps[i])
+			}
+		}
+		if changed {
+			if !emitCur(cur, fEnd) {
+				return
+			}
+			for i := range iters {
+				curProps[i] = trees[i].normalize(iters[i].prop)
+			}
+			cur = fEnd
+		}
+	}
+}
+
+// equivalent returns true if the two properties are equal or are both zero.
+func (t *T[B, P]) equivalent(a, b P) bool {
+	if t.propEq(a, b) {
+		return true
+	}
+	var zeroProp P
+	return t.propEq(a, zeroProp) && t.propEq(b, zeroProp)
+}
+
+func minBoundary[B Boundary](cmp func(x, y B) int, a, b B) B {
+	if cmp(a, b) <= 0 {
+		return a
+	}
+	return b
+}
+
+func maxBoundary[B Boundary](cmp func(x, y B) int, a, b B) B {
+	if cmp(a, b) >= 0 {
+		return a
+	}
+	return b
+}
+
+// zip iterates through two trees in lockstep, emitting the fragments of
+// [start, end) delimited by the boundaries of both trees, along with the
+// property of each tree in that fragment. Zero-property fragments are emitted
+// as well; consecutive fragments are not coalesced.
+//
+// Stops once emit returns false.
+func zip[B Boundary, P1, P2 Property](
+	t1 *T[B, P1],
+	t2 *T[B, P2],
+	start, end B,
+	emit func(start, end B, p1 P1, p2 P2) bool,
+) {
+	if t1.cmp(start, end) >= 0 {
+		return
+	}
+	_, p1 := t1.endBoundaryInfo(start)
+	_, p2 := t2.endBoundaryInfo(start)
+	next1, stop1 := iter.Pull2(t1.tree.Ascend(btreemap.GT(start), btreemap.LT(end)))
+	defer stop1()
+	next2, stop2 := iter.Pull2(t2.tree.Ascend(btreemap.GT(start), btreemap.LT(end)))
+	defer stop2()
+	b1, nextP1, ok1 := next1()
+	b2, nextP2, ok2 := next2()
+	for cur := start; ; {
+		var fEnd B
+		switch {
+		case !ok1 && !ok2:
+			emit(cur, end, t1.normalize(p1), t2.normalize(p2))
+			return
+		case !ok2 || (ok1 && t1.cmp(b1, b2) <= 0):
+			fEnd = b1
+		default:
+			fEnd = b2
+		}
+		if !emit(cur, fEnd, t1.normalize(p1), t2.normalize(p2)) {
+			return
+		}
+		if ok1 && t1.cmp(b1, fEnd) == 0 {
+			p1 = nextP1
+			b1, nextP1, ok1 = next1()
+		}
+		if ok2 && t1.cmp(b2, fEnd) == 0 {
+			p2 = nextP2
+			b2, nextP2, ok2 = next2()
+		}
+		cur = fEnd
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/RaduBerinde/axisds"
+)
+
+func TestSubtract(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt1, n1 := randomTree(rng, valRange)
+		rt2, n2 := randomTree(rng, valRange)
+		rt1.Subtract(&rt2)
+		rt1.CheckInvariants()
+		for i := range n2.values {
+			if n2.values[i] != 0 {
+				n1.values[i] = 0
+			}
+		}
+		checkEqual(t, &rt1, &n1, fmt.Sprintf("seed: %d", seed))
+	}
+}
+
+func TestComplement(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		a, b := rng.IntN(valRange), rng.IntN(valRange)
+		c := rt.Complement(a, b, 7)
+		c.CheckInvariants()
+		var expected naiveInts
+		for i := a; i < b; i++ {
+			if n.values[i] == 0 {
+				expected.values[i] = 7
+			}
+		}
+		checkEqual(t, &c, &expected, fmt.Sprintf("seed: %d", seed))
+	}
+}
+
+func TestEqual(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(20) + 1
+		rt1, n1 := randomTree(rng, valRange)
+		rt2, n2 := randomTree(rng, valRange)
+		if expected, actual := n1 == n2, rt1.Equal(&rt2); expected != actual {
+			t.Fatalf("Equal returned %t instead of %t\nseed: %d", actual, expected, seed)
+		}
+		// Create a copy of rt1 with a different internal structure.
+		rt3 := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+		for i, numOps := 0, rng.IntN(10); i < numOps; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			rt3.Update(a, b, func(p int) int { return p + 1 })
+		}
+		rt3.Update(0, maxRange, func(p int) int { return 0 })
+		rt1.EnumerateAll(func(start, end, prop int) bool {
+			for i := start; i < end; i++ {
+				rt3.Update(i, i+1, func(int) int { return prop })
+			}
+			return true
+		})
+		if !rt1.Equal(&rt3) || !rt3.Equal(&rt1) {
+			t.Fatalf("trees not equal\n%s\n%s\nseed: %d", rt1.String(intervalFmt), rt3.String(intervalFmt), seed)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt1, n1 := randomTree(rng, valRange)
+		rt2, n2 := randomTree(rng, valRange)
+		var actual strings.Builder
+		rt1.Diff(&rt2, func(start, end, oldProp, newProp int) bool {
+			fmt.Fprintf(&actual, "[%d, %d) %d -> %d\n", start, end, oldProp, newProp)
+			return true
+		})
+		var expected strings.Builder
+		for i := 0; i < maxRange; {
+			if n1.values[i] == n2.values[i] {
+				i++
+				continue
+			}
+			j := i + 1
+			for j < maxRange && n1.values[j] == n1.values[i] && n2.values[j] == n2.values[i] {
+				j++
+			}
+			fmt.Fprintf(&expected, "[%d, %d) %d -> %d\n", i, j, n1.values[i], n2.values[i])
+			i = j
+		}
+		if actual.String() != expected.String() {
+			t.Fatalf("Diff mismatch:\n%sexpected:\n%s\nseed: %d", actual.String(), expected.String(), seed)
+		}
+	}
+}
+
+func TestZipEnumerate(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt1, n1 := randomTree(rng, valRange)
+		rt2, n2 := randomTree(rng, valRange)
+		a, b := rng.IntN(valRange+1), rng.IntN(valRange+1)
+		var actual strings.Builder
+		ZipEnumerate(&rt1, &rt2, a, b, func(start, end, p1, p2 int) bool {
+			fmt.Fprintf(&actual, "[%d, %d) %d %d\n", start, end, p1, p2)
+			return true
+		})
+		var expected strings.Builder
+		for i := a; i < b; {
+			if n1.values[i] == 0 && n2.values[i] == 0 {
+				i++
+				continue
+			}
+			j := i + 1
+			for j < b && n1.values[j] == n1.values[i] && n2.values[j] == n2.values[i] {
+				j++
+			}
+			fmt.Fprintf(&expected, "[%d, %d) %d %d\n", i, j, n1.values[i], n2.values[i])
+			i = j
+		}
+		if actual.String() != expected.String() {
+			t.Fatalf("ZipEnumerate(%d, %d) mismatch:\n%sexpected:\n%s\nseed: %d", a, b, actual.String(), expected.String(), seed)
+		}
+	}
+}
+
+func TestZipEnumerateN(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		numTrees := rng.IntN(5) + 1
+		trees := make([]*T[int, int], numTrees)
+		naive := make([]naiveInts, numTrees)
+		for i := range trees {
+			rt, n := randomTree(rng, valRange)
+			trees[i], naive[i] = &rt, n
+		}
+		a, b := rng.IntN(valRange+1), rng.IntN(valRange+1)
+		var actual strings.Builder
+		ZipEnumerateN(trees, a, b, func(start, end int, props []int) bool {
+			fmt.Fprintf(&actual, "[%d, %d) %v\n", start, end, props)
+			return true
+		})
+		propsAt := func(i int) []int {
+			props := make([]int, numTrees)
+			for j := range naive {
+				props[j] = naive[j].values[i]
+			}
+			return props
+		}
+		var expected strings.Builder
+		for i := a; i < b; {
+			props := propsAt(i)
+			j := i + 1
+			for j < b && slices.Equal(propsAt(j), props) {
+				j++
+			}
+			if slices.ContainsFunc(props, func(p int) bool { return p != 0 }) {
+				fmt.Fprintf(&expected, "[%d, %d) %v\n", i, j, props)
+			}
+			i = j
+		}
+		if actual.String() != expected.String() {
+			t.Fatalf("ZipEnumerateN(%d, %d) mismatch:\n%sexpected:\n%s\nseed: %d", a, b, actual.String(), expected.String(), seed)
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		keep := Make[int, bool](cmp.Compare[int], func(a, b bool) bool { return a == b })
+		var nKeep [maxRange]bool
+		for i, numIntervals := 0, rng.IntN(5); i < numIntervals; i++ {
+			a := rng.IntN(valRange)
+			b := a + rng.IntN(valRange-a+1)
+			keep.Set(a, b, true)
+			for j := a; j < b; j++ {
+				nKeep[j] = true
+			}
+		}
+		Clip(&rt, &keep)
+		rt.CheckInvariants()
+		for i := range n.values {
+			if !nKeep[i] {
+				n.values[i] = 0
+			}
+		}
+		checkEqual(t, &rt, &n, fmt.Sprintf("seed: %d", seed))
+	}
+}
+
+func TestUpdateMasked(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		mask, nMask := randomTree(rng, valRange)
+		a, b := rng.IntN(valRange+1), rng.IntN(valRange+1)
+		delta := rng.IntN(3)
+		changed := UpdateMasked(&rt, &mask, a, b, func(p int) int { return p + delta })
+		rt.CheckInvariants()
+		expectedChanged := false
+		for i := a; i < b; i++ {
+			if nMask.values[i] != 0 && delta != 0 {
+				n.values[i] += delta
+				expectedChanged = true
+			}
+		}
+		context := fmt.Sprintf("UpdateMasked(%d, %d) += %d\nseed: %d", a, b, delta, seed)
+		if changed != expectedChanged {
+			t.Fatalf("changed = %t instead of %t\n%s", changed, expectedChanged, context)
+		}
+		checkEqual(t, &rt, &n, context)
+	}
+}
+
+var intervalFmt = axisds.MakeIntervalFormatter(axisds.MakeBoundaryFormatter[int]())
+
+// randomTree generates a random tree with boundaries in [0, valRange), along
+// with the equivalent naiveInts.
+func randomTree(rng *rand.Rand, valRange int) (T[int, int], naiveInts) {
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	var n naiveInts
+	for i, numOps := 0, rng.IntN(20); i < numOps; i++ {
+		a, b := rng.IntN(valRange), rng.IntN(valRange)
+		if a > b {
+			a, b = b, a
+		}
+		value := rng.IntN(5)
+		rt.Update(a, b, func(p int) int { return value })
+		n.Set(a, b, value)
+	}
+	return rt, n
+}
+
+// checkEqual verifies that the tree and the naiveInts contain the same regions.
+func checkEqual(t *testing.T, rt *T[int, int], n *naiveInts, context string) {
+	t.Helper()
+	var b1, b2 strings.Builder
+	rt.EnumerateAll(func(start, end, val int) bool {
+		fmt.Fprintf(&b1, "  [%d, %d) = %d\n", start, end, val)
+		return true
+	})
+	n.Enumerate(0, maxRange, func(start, end, val int) {
+		fmt.Fprintf(&b2, "  [%d, %d) = %d\n", start, end, val)
+	})
+	if b1.String() != b2.String() {
+		t.Fatalf("mismatch:\n%sexpected:\n%s\n%s", b1.String(), b2.String(), context)
+	}
+}
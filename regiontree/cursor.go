@@ -0,0 +1,76 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import "github.com/RaduBerinde/btreemap"
+
+// Cursor is used to efficiently perform a sequence of point lookups, typically
+// with increasing boundaries. It caches the region of the last lookup, so
+// lookups that fall in the same region don't need to search the tree.
+//
+// The cache is discarded automatically when the tree is modified. A Cursor
+// is not safe for concurrent use, but multiple cursors can be used
+// concurrently on the same tree (as long as the tree is not modified).
+type Cursor[B Boundary, P Property] struct {
+	t *T[B, P]
+	// version is the tree version at the time the region was cached; the cache
+	// is valid only if valid is set and the tree version has not changed.
+	version uint64
+	valid   bool
+	// The cached region [start, end) with property prop, delimited by two
+	// consecutive boundaries stored in the tree. If hasStart is false, the
+	// region starts before all boundaries; if hasEnd is false, it ends after
+	// all boundaries.
+	start, end       B
+	hasStart, hasEnd bool
+	prop             P
+}
+
+// NewCursor creates a cursor over the tree.
+func (t *T[B, P]) NewCursor() *Cursor[B, P] {
+	return &Cursor[B, P]{t: t}
+}
+
+// PropertyAt returns the property of the region that contains b (the zero
+// property if there is no such region).
+//
+// The runtime complexity is O(1) if b is in the same region as the previous
+// lookup (and the tree was not modified since), and O(log N) otherwise.
+func (c *Cursor[B, P]) PropertyAt(b B) P {
+	t := c.t.atNow()
+	if !c.valid || c.version != t.version ||
+		(c.hasStart && t.cmp(b, c.start) < 0) || (c.hasEnd && t.cmp(b, c.end) >= 0) {
+		c.seek(b)
+	}
+	return t.normalize(c.prop)
+}
+
+// seek searches the tree for the region that contains b and caches it.
+func (c *Cursor[B, P]) seek(b B) {
+	t := c.t
+	var zeroProp P
+	c.hasStart, c.prop = false, zeroProp
+	t.tree.DescendFunc(btreemap.LE(b), btreemap.Min[B](), func(rStart B, rProp P) bool {
+		c.start, c.prop, c.hasStart = rStart, rProp, true
+		return false
+	})
+	c.hasEnd = false
+	t.tree.AscendFunc(btreemap.GT(b), btreemap.Max[B](), func(rEnd B, _ P) bool {
+		c.end, c.hasEnd = rEnd, true
+		return false
+	})
+	c.version = t.version
+	c.valid = true
+}
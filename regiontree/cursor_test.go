@@ -0,0 +1,52 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		c := rt.NewCursor()
+		pos := 0
+		for i := 0; i < 200; i++ {
+			switch rng.IntN(20) {
+			case 0:
+				// Occasionally move backwards.
+				pos = rng.IntN(valRange)
+			case 1:
+				// Modify the tree; the cursor must not return stale properties.
+				a, b := rng.IntN(valRange), rng.IntN(valRange)
+				if a > b {
+					a, b = b, a
+				}
+				value := rng.IntN(5)
+				rt.Set(a, b, value)
+				n.Set(a, b, value)
+			default:
+				pos = min(pos+rng.IntN(3), valRange)
+			}
+			if actual, expected := c.PropertyAt(pos), n.values[pos]; actual != expected {
+				t.Fatalf("PropertyAt(%d) = %d instead of %d\nseed: %d", pos, actual, expected, seed)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import "github.com/RaduBerinde/axisds"
+
+// EndpointT is a region tree with axisds.Endpoint[B] boundaries, which allows
+// regions with inclusive or exclusive start and end points. Its methods take
+// plain B boundaries along with inclusivity flags.
+//
+// The underlying T[axisds.Endpoint[B], P] is available via Tree, for the
+// operations that are not directly exposed.
+type EndpointT[B Boundary, P Property] struct {
+	t T[axisds.Endpoint[B], P]
+}
+
+// MakeEndpointT creates a new region tree with the given boundary and property
+// comparison functions.
+func MakeEndpointT[B Boundary, P Property](
+	cmp axisds.CompareFn[B], propEq PropertyEqualFn[P],
+) EndpointT[B, P] {
+	return MakeEndpointTWithOptions(cmp, propEq, Options[axisds.Endpoint[B], P]{})
+}
+
+// MakeEndpointTWithOptions creates a new region tree with the given boundary
+// and property comparison functions and options.
+func MakeEndpointTWithOptions[B Boundary, P Property](
+	cmp axisds.CompareFn[B], propEq PropertyEqualFn[P], opts Options[axisds.Endpoint[B], P],
+) EndpointT[B, P] {
+	return EndpointT[B, P]{t: MakeWithOptions(axisds.EndpointCompareFn(cmp), propEq, opts)}
+}
+
+// Tree returns the underlying region tree.
+func (et *EndpointT[B, P]) Tree() *T[axisds.Endpoint[B], P] {
+	return &et.t
+}
+
+// Update the property for the given range. See T.Update.
+func (et *EndpointT[B, P]) Update(
+	start B, startIncl bool, end B, endIncl bool, updateProp func(p P) P,
+) (changed bool) {
+	s, e := endpoints(start, startIncl, end, endIncl)
+	return et.t.Update(s, e, updateProp)
+}
+
+// Set the property for the given range. See T.Set.
+func (et *EndpointT[B, P]) Set(start B, startIncl bool, end B, endIncl bool, prop P) {
+	s, e := endpoints(start, startIncl, end, endIncl)
+	et.t.Set(s, e, prop)
+}
+
+// Delete the given range (setting the property to zero). See T.Delete.
+func (et *EndpointT[B, P]) Delete(start B, startIncl bool, end B, endIncl bool) {
+	s, e := endpoints(start, startIncl, end, endIncl)
+	et.t.Delete(s, e)
+}
+
+// Enumerate all regions in the given range with non-zero property. See
+// T.Enumerate.
+func (et *EndpointT[B, P]) Enumerate(
+	start B, startIncl bool, end B, endIncl bool, emit func(start, end axisds.Endpoint[B], prop P) bool,
+) {
+	s, e := endpoints(start, startIncl, end, endIncl)
+	et.t.Enumerate(s, e, emit)
+}
+
+// Any returns true if the tree has a region in the given range for which
+// propFn returns true. See T.Any.
+func (et *EndpointT[B, P]) Any(
+	start B, startIncl bool, end B, endIncl bool, propFn func(prop P) bool,
+) bool {
+	s, e := endpoints(start, startIncl, end, endIncl)
+	return et.t.Any(s, e, propFn)
+}
+
+// Overlaps returns true if the tree has a region with non-zero property within
+// the given range. See T.Overlaps.
+func (et *EndpointT[B, P]) Overlaps(start B, startIncl bool, end B, endIncl bool) bool {
+	s, e := endpoints(start, startIncl, end, endIncl)
+	return et.t.Overlaps(s, e)
+}
+
+// PropertyAt returns the property at the given point.
+//
+// The runtime complexity is O(log N).
+func (et *EndpointT[B, P]) PropertyAt(b B) P {
+	s, e := endpoints(b, true, b, true)
+	prop, _ := et.t.RangeProperty(s, e)
+	return prop
+}
+
+// String formats all regions, one per line, using the given boundary
+// formatter.
+func (et *EndpointT[B, P]) String(bFmt axisds.BoundaryFormatter[B]) string {
+	return et.t.String(axisds.MakeEndpointIntervalFormatter(bFmt))
+}
+
+// endpoints returns the endpoints for the given range.
+func endpoints[B Boundary](
+	start B, startIncl bool, end B, endIncl bool,
+) (s, e axisds.Endpoint[B]) {
+	return axisds.MakeEndpoints(start, axisds.InclusiveIf(startIncl), end, axisds.InclusiveIf(endIncl))
+}
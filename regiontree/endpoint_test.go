@@ -0,0 +1,79 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/RaduBerinde/axisds"
+)
+
+func TestEndpointT(t *testing.T) {
+	et := MakeEndpointT[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	et.Set(1, true, 5, true, 1)
+	et.Update(5, false, 10, false, func(p int) int { return p + 2 })
+	et.Update(3, true, 7, true, func(p int) int { return p + 10 })
+	et.Delete(8, true, 9, false)
+
+	bFmt := axisds.MakeBoundaryFormatter[int]()
+	expected := "[1, 3) = 1\n[3, 5] = 11\n(5, 7] = 12\n(7, 8) = 2\n[9, 10) = 2\n"
+	if res := et.String(bFmt); res != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, res)
+	}
+	for b, expected := range map[int]int{0: 0, 1: 1, 3: 11, 5: 11, 6: 12, 7: 12, 8: 0, 9: 2, 10: 0} {
+		if res := et.PropertyAt(b); res != expected {
+			t.Errorf("PropertyAt(%d) = %d instead of %d", b, res, expected)
+		}
+	}
+	if et.Overlaps(8, true, 9, false) || !et.Overlaps(8, true, 9, true) {
+		t.Errorf("incorrect Overlaps")
+	}
+	if !et.Any(5, false, 6, false, func(p int) bool { return p == 12 }) || et.Any(5, true, 5, true, func(p int) bool { return p == 12 }) {
+		t.Errorf("incorrect Any")
+	}
+	var res []string
+	et.Enumerate(2, false, 5, true, func(start, end axisds.Endpoint[int], prop int) bool {
+		res = append(res, axisds.MakeEndpointIntervalFormatter(bFmt)(start, end))
+		return true
+	})
+	if len(res) != 2 || res[0] != "(2, 3)" || res[1] != "[3, 5]" {
+		t.Errorf("incorrect Enumerate: %v", res)
+	}
+	et.Tree().CheckInvariants()
+}
+
+// TestEndpointTDiscrete checks that with axisds.CanonicalEndpoint, adjacent
+// closed intervals are coalesced.
+func TestEndpointTDiscrete(t *testing.T) {
+	d := axisds.MakeIntegerDiscrete[int]()
+	et := MakeEndpointTWithOptions[int, int](cmp.Compare[int], func(a, b int) bool { return a == b },
+		Options[axisds.Endpoint[int], int]{
+			NormalizeBoundary: func(e axisds.Endpoint[int]) axisds.Endpoint[int] {
+				return axisds.CanonicalEndpoint(d, e)
+			},
+		})
+	et.Set(1, true, 2, true, 1)
+	et.Set(3, true, 5, true, 1)
+	et.Set(5, false, 7, false, 2)
+	expected := "[1, 6) = 1\n[6, 7) = 2\n"
+	if res := et.String(axisds.MakeBoundaryFormatter[int]()); res != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, res)
+	}
+	if et.PropertyAt(6) != 2 || et.PropertyAt(5) != 1 {
+		t.Fatalf("incorrect PropertyAt")
+	}
+	et.Tree().CheckInvariants()
+}
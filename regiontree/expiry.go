@@ -0,0 +1,130 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"slices"
+	"time"
+)
+
+// ExpireUpTo removes all regions with properties that have a deadline at or
+// before now (even if the clock has not reached now yet) and removes the
+// boundaries of expired regions. Options.Deadline must be set.
+//
+// The runtime complexity is O(N) plus O(log N) for each boundary that changes.
+func (t *T[B, P]) ExpireUpTo(now time.Time) {
+	if t.opts.Deadline == nil {
+		panic("ExpireUpTo requires Options.Deadline")
+	}
+	defer t.endOp(t.startOp())
+	t.DeleteWhere(func(p P) bool {
+		return !t.opts.Deadline(p).After(now)
+	})
+	t.Compact()
+}
+
+// startOp is called at the beginning of an operation that modifies the tree.
+// It increments the tree version (see Cursor) and, if Options.Deadline is set,
+// it reads the clock so that the entire operation uses the same time. It
+// returns whether the caller must call endOp; nested operations use the time
+// of the outermost operation.
+//
+// Usage:
+//
+//	defer t.endOp(t.startOp())
+func (t *T[B, P]) startOp() bool {
+	t.version++
+	if t.opts.Deadline == nil || t.hasNow {
+		return false
+	}
+	t.now, t.hasNow = t.opts.Clock(), true
+	return true
+}
+
+// endOp is called at the end of an operation; see startOp.
+func (t *T[B, P]) endOp(started bool) {
+	if started {
+		t.now, t.hasNow = time.Time{}, false
+	}
+}
+
+// atNow is called at the beginning of a query. If Options.Deadline is set, it
+// reads the clock and returns a shallow copy of t which uses that time for the
+// entire query; otherwise it returns t. Queries can run concurrently, so they
+// cannot use startOp.
+func (t *T[B, P]) atNow() *T[B, P] {
+	if t.opts.Deadline == nil || t.hasNow {
+		return t
+	}
+	c := *t
+	c.now, c.hasNow = t.opts.Clock(), true
+	return &c
+}
+
+// treesAtNow returns the given trees, each using a single reading of the clock
+// for the entire query (see atNow). The slice is copied if necessary.
+func treesAtNow[B Boundary, P Property](trees []*T[B, P]) []*T[B, P] {
+	res := trees
+	cloned := false
+	for i, t := range trees {
+		if c := t.atNow(); c != t {
+			if !cloned {
+				res, cloned = slices.Clone(trees), true
+			}
+			res[i] = c
+		}
+	}
+	return res
+}
+
+// expired returns whether the given property has expired, according to
+// Options.IsExpired and Options.Deadline.
+func (t *T[B, P]) expired(prop P) bool {
+	if t.opts.IsExpired != nil && t.opts.IsExpired(prop) {
+		return true
+	}
+	if t.opts.Deadline != nil {
+		now := t.now
+		if !t.hasNow {
+			// Not called from within an operation (e.g. from a Builder).
+			now = t.opts.Clock()
+		}
+		return !t.opts.Deadline(prop).After(now)
+	}
+	return false
+}
+
+// propEq returns whether two properties are equivalent: either they are equal
+// according to the PropertyEqualFn, or they are both zero or expired.
+func (t *T[B, P]) propEq(a, b P) bool {
+	if t.eq(a, b) {
+		return true
+	}
+	if t.opts.IsExpired == nil && t.opts.Deadline == nil {
+		return false
+	}
+	var zeroProp P
+	return (t.expired(a) || t.eq(a, zeroProp)) && (t.expired(b) || t.eq(b, zeroProp))
+}
+
+// normalize returns the zero property if the given property has expired, and
+// the property itself otherwise.
+func (t *T[B, P]) normalize(prop P) P {
+	if t.expired(prop) {
+		var zeroProp P
+		return zeroProp
+	}
+	return prop
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestExpiry(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+
+		// Properties are "expiration times"; anything below the watermark has
+		// expired.
+		watermark := 0
+		rt := MakeWithOptions(cmp.Compare[int], func(a, b int) bool { return a == b }, Options[int, int]{
+			IsExpired: func(p int) bool { return p < watermark },
+		})
+		var n naiveInts
+		expire := func() {
+			for i := range n.values {
+				if n.values[i] < watermark {
+					n.values[i] = 0
+				}
+			}
+		}
+
+		for op := 0; op < 100; op++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			value := watermark + rng.IntN(20)
+			switch rng.IntN(6) {
+			case 0:
+				watermark += rng.IntN(5)
+			case 1:
+				rt.Set(a, b, value)
+				n.Set(a, b, value)
+			case 2:
+				// Update functions must never see expired properties.
+				rt.Update(a, b, func(p int) int {
+					if p != 0 && p < watermark {
+						t.Fatalf("update saw expired property %d; seed: %d", p, seed)
+					}
+					return max(p, value)
+				})
+				for i := a; i < b; i++ {
+					n.values[i] = max(n.values[i], value)
+				}
+			case 3:
+				rt.Compact()
+			case 4:
+				rt.EnumerateWithGC(a, b, func(start, end, prop int) bool { return true })
+			case 5:
+				for i := 0; i < 10; i++ {
+					x := rng.IntN(valRange)
+					expire()
+					if actual, _ := rt.RangeProperty(x, x+1); actual != n.values[x] {
+						t.Fatalf("RangeProperty(%d, %d) = %d instead of %d; seed: %d", x, x+1, actual, n.values[x], seed)
+					}
+				}
+			}
+			expire()
+			rt.CheckInvariants()
+			checkEqual(t, &rt, &n, fmt.Sprintf("watermark: %d\nseed: %d", watermark, seed))
+		}
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+
+		// Properties are deadlines, in seconds since base.
+		base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		seconds := 0
+		rt := MakeWithOptions(cmp.Compare[int], func(a, b int) bool { return a == b }, Options[int, int]{
+			Deadline: func(p int) time.Time { return base.Add(time.Duration(p) * time.Second) },
+			Clock:    func() time.Time { return base.Add(time.Duration(seconds) * time.Second) },
+		})
+		var n naiveInts
+		expireUpTo := func(s int) {
+			for i := range n.values {
+				if n.values[i] <= s {
+					n.values[i] = 0
+				}
+			}
+		}
+
+		for op := 0; op < 100; op++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			switch rng.IntN(4) {
+			case 0:
+				seconds += rng.IntN(5)
+			case 1:
+				value := seconds + 1 + rng.IntN(20)
+				rt.Set(a, b, value)
+				n.Set(a, b, value)
+			case 2:
+				rt.Update(a, b, func(p int) int { return p + 1 })
+				for i := a; i < b; i++ {
+					if n.values[i] > seconds {
+						n.values[i]++
+					} else {
+						// Expired properties are passed as zero.
+						n.values[i] = 1
+					}
+				}
+			case 3:
+				// Expire up to a time in the future.
+				s := seconds + rng.IntN(5)
+				rt.ExpireUpTo(base.Add(time.Duration(s) * time.Second))
+				expireUpTo(s)
+				expected := 0
+				n.Enumerate(0, valRange, func(start, end, val int) { expected += 2 })
+				if actual := rt.InternalLen(); actual > expected {
+					t.Fatalf("InternalLen() = %d, expected at most %d; seed: %d", actual, expected, seed)
+				}
+			}
+			expireUpTo(seconds)
+			rt.CheckInvariants()
+			checkEqual(t, &rt, &n, fmt.Sprintf("seconds: %d\nseed: %d", seconds, seed))
+		}
+	}
+}
+
+func TestDeadlineClockReads(t *testing.T) {
+	// The clock advances by one second every time it is read; each operation
+	// must read it at most once.
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	reads := 0
+	rt := MakeWithOptions(cmp.Compare[int], func(a, b int) bool { return a == b }, Options[int, int]{
+		Deadline: func(p int) time.Time { return base.Add(time.Duration(p) * time.Second) },
+		Clock: func() time.Time {
+			reads++
+			return base.Add(time.Duration(reads) * time.Second)
+		},
+	})
+	expectReads := func(op string, fn func()) {
+		t.Helper()
+		reads0 := reads
+		fn()
+		if n := reads - reads0; n > 1 {
+			t.Fatalf("%s read the clock %d times", op, n)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		expectReads("Set", func() { rt.Set(i, i+1, 1000+i) })
+	}
+	expectReads("Update", func() {
+		rt.Update(0, 100, func(p int) int { return p - 1000 + reads })
+	})
+	expectReads("EnumerateAll", func() {
+		rt.EnumerateAll(func(start, end, prop int) bool { return true })
+	})
+	expectReads("RangeProperty", func() { rt.RangeProperty(0, 100) })
+	expectReads("Compact", func() { rt.Compact() })
+	expectReads("DeleteWhere", func() { rt.DeleteWhere(func(p int) bool { return p%2 == 0 }) })
+	expectReads("Clone", func() {
+		c := rt.Clone()
+		c.Set(0, 50, 0)
+	})
+	rt.CheckInvariants()
+}
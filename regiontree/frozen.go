@@ -0,0 +1,187 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"iter"
+
+	"github.com/RaduBerinde/axisds"
+)
+
+// Frozen is a read-only view of a region tree, obtained via T.Freeze. None of
+// its methods modify the internal structure, so a Frozen can be used
+// concurrently from multiple goroutines without synchronization.
+//
+// If the PropertyEqualFn evolves over time, it must not change while Frozen
+// methods are running.
+type Frozen[B Boundary, P Property] struct {
+	t T[B, P]
+}
+
+// Freeze returns a read-only view of the tree as it is now. Subsequent changes
+// to the tree are not reflected in the view.
+//
+// Freeze is a constant time operation (it uses Clone). Like Clone, it must not
+// be called concurrently with other methods.
+func (t *T[B, P]) Freeze() Frozen[B, P] {
+	return Frozen[B, P]{t: t.Clone()}
+}
+
+// Enumerate is the read-only equivalent of T.Enumerate.
+func (f *Frozen[B, P]) Enumerate(start, end B, emit func(start, end B, prop P) bool) {
+	f.t.Enumerate(start, end, emit)
+}
+
+// EnumerateN is the read-only equivalent of T.EnumerateN.
+func (f *Frozen[B, P]) EnumerateN(start, end B, n int, emit func(start, end B, prop P)) (truncated bool) {
+	return f.t.EnumerateN(start, end, n, emit)
+}
+
+// EnumeratePage is the read-only equivalent of T.EnumeratePage.
+func (f *Frozen[B, P]) EnumeratePage(
+	start, end B, limit int, emit func(start, end B, prop P),
+) (next B, more bool) {
+	return f.t.EnumeratePage(start, end, limit, emit)
+}
+
+// AppendRegions is the read-only equivalent of T.AppendRegions.
+func (f *Frozen[B, P]) AppendRegions(dst []Region[B, P], start, end B) []Region[B, P] {
+	return f.t.AppendRegions(dst, start, end)
+}
+
+// EnumerateAll is the read-only equivalent of T.EnumerateAll.
+func (f *Frozen[B, P]) EnumerateAll(emit func(start, end B, prop P) bool) {
+	f.t.EnumerateAll(emit)
+}
+
+// EnumerateDescending is the read-only equivalent of T.EnumerateDescending.
+func (f *Frozen[B, P]) EnumerateDescending(start, end B, emit func(start, end B, prop P) bool) {
+	f.t.EnumerateDescending(start, end, emit)
+}
+
+// EnumerateGaps is the read-only equivalent of T.EnumerateGaps.
+func (f *Frozen[B, P]) EnumerateGaps(start, end B, emit func(start, end B) bool) {
+	f.t.EnumerateGaps(start, end, emit)
+}
+
+// FindFirst is the read-only equivalent of T.FindFirst.
+func (f *Frozen[B, P]) FindFirst(start, end B, pred func(prop P) bool) (rStart, rEnd B, prop P, ok bool) {
+	return f.t.FindFirst(start, end, pred)
+}
+
+// CountWhere is the read-only equivalent of T.CountWhere.
+func (f *Frozen[B, P]) CountWhere(start, end B, pred func(prop P) bool) int {
+	return f.t.CountWhere(start, end, pred)
+}
+
+// FloorBoundary is the read-only equivalent of T.FloorBoundary.
+func (f *Frozen[B, P]) FloorBoundary(b B) (_ B, ok bool) {
+	return f.t.FloorBoundary(b)
+}
+
+// CeilingBoundary is the read-only equivalent of T.CeilingBoundary.
+func (f *Frozen[B, P]) CeilingBoundary(b B) (_ B, ok bool) {
+	return f.t.CeilingBoundary(b)
+}
+
+// Neighbors is the read-only equivalent of T.Neighbors.
+func (f *Frozen[B, P]) Neighbors(b B) (prev, cur, next *Region[B, P]) {
+	return f.t.Neighbors(b)
+}
+
+// EnumerateWithGaps is the read-only equivalent of T.EnumerateWithGaps.
+func (f *Frozen[B, P]) EnumerateWithGaps(start, end B, emit func(start, end B, prop P) bool) {
+	f.t.EnumerateWithGaps(start, end, emit)
+}
+
+// Any is the read-only equivalent of T.Any.
+func (f *Frozen[B, P]) Any(start, end B, propFn func(prop P) bool) bool {
+	return f.t.Any(start, end, propFn)
+}
+
+// Overlaps is the read-only equivalent of T.Overlaps.
+func (f *Frozen[B, P]) Overlaps(start, end B) bool {
+	return f.t.Overlaps(start, end)
+}
+
+// RangeProperty is the read-only equivalent of T.RangeProperty.
+func (f *Frozen[B, P]) RangeProperty(start, end B) (prop P, ok bool) {
+	return f.t.RangeProperty(start, end)
+}
+
+// IsUniform is the read-only equivalent of T.IsUniform.
+func (f *Frozen[B, P]) IsUniform(start, end B, pred func(prop P) bool) bool {
+	return f.t.IsUniform(start, end, pred)
+}
+
+// Boundaries is the read-only equivalent of T.Boundaries.
+func (f *Frozen[B, P]) Boundaries(start, end B) iter.Seq[B] {
+	return f.t.Boundaries(start, end)
+}
+
+// ContainsRange is the read-only equivalent of T.ContainsRange.
+func (f *Frozen[B, P]) ContainsRange(start, end B) bool {
+	return f.t.ContainsRange(start, end)
+}
+
+// SeekNonZero is the read-only equivalent of T.SeekNonZero.
+func (f *Frozen[B, P]) SeekNonZero(b B) (start, end B, prop P, ok bool) {
+	return f.t.SeekNonZero(b)
+}
+
+// PrevNonZero is the read-only equivalent of T.PrevNonZero.
+func (f *Frozen[B, P]) PrevNonZero(b B) (start, end B, prop P, ok bool) {
+	return f.t.PrevNonZero(b)
+}
+
+// Bounds is the read-only equivalent of T.Bounds.
+func (f *Frozen[B, P]) Bounds() (min, max B, ok bool) {
+	return f.t.Bounds()
+}
+
+// Len is the read-only equivalent of T.Len.
+func (f *Frozen[B, P]) Len() int {
+	return f.t.Len()
+}
+
+// IsEmpty is the read-only equivalent of T.IsEmpty.
+func (f *Frozen[B, P]) IsEmpty() bool {
+	return f.t.IsEmpty()
+}
+
+// Thaw returns a new (modifiable) tree with the same regions. The operation is
+// constant time (it uses Clone) but it must not be called concurrently with
+// other Thaw calls.
+func (f *Frozen[B, P]) Thaw() T[B, P] {
+	return f.t.Clone()
+}
+
+// String is the read-only equivalent of T.String.
+func (f *Frozen[B, P]) String(iFmt axisds.IntervalFormatter[B]) string {
+	return f.t.String(iFmt)
+}
+
+// DebugString is the read-only equivalent of T.DebugString.
+func (f *Frozen[B, P]) DebugString(bFmt axisds.BoundaryFormatter[B]) string {
+	return f.t.DebugString(bFmt)
+}
+
+// StringWithPropFormatter is the read-only equivalent of
+// T.StringWithPropFormatter.
+func (f *Frozen[B, P]) StringWithPropFormatter(
+	iFmt axisds.IntervalFormatter[B], propFmt func(P) string,
+) string {
+	return f.t.StringWithPropFormatter(iFmt, propFmt)
+}
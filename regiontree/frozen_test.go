@@ -0,0 +1,68 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"sync"
+	"testing"
+)
+
+func TestFrozen(t *testing.T) {
+	// Use an evolving equality function.
+	lowWatermark := 0
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool {
+		return a == b || (a < lowWatermark && b < lowWatermark)
+	})
+	for i := 0; i < 100; i++ {
+		rt.Set(i*10, i*10+5, i+1)
+	}
+	f := rt.Freeze()
+	rt.Set(0, 1000, 1)
+	if f.Len() != 100 {
+		t.Fatalf("frozen view changed")
+	}
+
+	lowWatermark = 50
+	boundaries := f.t.InternalLen()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			f.EnumerateAll(func(start, end, prop int) bool {
+				n++
+				return true
+			})
+			if n != 51 || f.Len() != 51 || f.IsEmpty() {
+				t.Errorf("unexpected number of regions %d", n)
+			}
+			if !f.Overlaps(500, 505) || f.Overlaps(0, 300) || f.ContainsRange(500, 510) {
+				t.Errorf("unexpected query results")
+			}
+		}()
+	}
+	wg.Wait()
+	if f.t.InternalLen() != boundaries {
+		t.Fatalf("frozen tree was modified")
+	}
+
+	thawed := f.Thaw()
+	thawed.Compact()
+	if thawed.InternalLen() != 102 || f.t.InternalLen() != boundaries {
+		t.Fatalf("unexpected boundaries %d", thawed.InternalLen())
+	}
+}
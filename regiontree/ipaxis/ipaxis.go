@@ -0,0 +1,100 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipaxis contains helpers for region trees with netip.Addr boundaries,
+// e.g. for IP allow/deny lists or address ownership maps.
+//
+// Addresses are ordered as in netip.Addr.Compare: all IPv4 addresses sort
+// before all IPv6 addresses.
+package ipaxis
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/RaduBerinde/axisds"
+	"github.com/RaduBerinde/axisds/regiontree"
+)
+
+// Compare is an axisds.CompareFn[netip.Addr].
+func Compare(x, y netip.Addr) int {
+	return x.Compare(y)
+}
+
+var _ axisds.CompareFn[netip.Addr] = Compare
+
+// Make creates a new region tree with netip.Addr boundaries.
+func Make[P regiontree.Property](propEq regiontree.PropertyEqualFn[P]) regiontree.T[netip.Addr, P] {
+	return regiontree.Make[netip.Addr, P](Compare, propEq)
+}
+
+// PrefixRange returns the half-open interval [start, end) of the addresses in
+// the given prefix. If the prefix extends to the last address of its family
+// (e.g. 255.0.0.0/8), the range has no valid end and ok is false; see
+// PrefixInterval for an alternative.
+//
+// Panics if the prefix is not valid.
+func PrefixRange(p netip.Prefix) (start, end netip.Addr, ok bool) {
+	first, last := prefixBounds(p)
+	end = last.Next()
+	return first, end, end.IsValid()
+}
+
+// PrefixInterval returns the interval of the addresses in the given prefix, as
+// Endpoint boundaries with an inclusive end; the result can be used with a
+// region tree created with regiontree.MakeEndpointT(Compare, ...). Unlike
+// PrefixRange, it works for any prefix.
+//
+// Panics if the prefix is not valid.
+func PrefixInterval(p netip.Prefix) (start, end axisds.Endpoint[netip.Addr]) {
+	first, last := prefixBounds(p)
+	return axisds.MakeEndpoints(first, axisds.Inclusive, last, axisds.Inclusive)
+}
+
+// prefixBounds returns the first and the last address in the prefix.
+func prefixBounds(p netip.Prefix) (first, last netip.Addr) {
+	if !p.IsValid() {
+		panic(fmt.Sprintf("invalid prefix %v", p))
+	}
+	p = p.Masked()
+	first = p.Addr()
+	b := first.AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	last, _ = netip.AddrFromSlice(b)
+	return first, last
+}
+
+// MakeFormatter creates a BoundaryFormatter[netip.Addr] that uses the standard
+// dotted (IPv4) or colon (IPv6) notation.
+func MakeFormatter() axisds.BoundaryFormatter[netip.Addr] {
+	return func(b netip.Addr) string {
+		return b.String()
+	}
+}
+
+// MakeParser creates a Parser[netip.Addr] for the standard dotted (IPv4) or
+// colon (IPv6) notation; it is the counterpart of MakeFormatter.
+func MakeParser() axisds.Parser[netip.Addr] {
+	return axisds.MakeFuncParser(parseBoundary)
+}
+
+func parseBoundary(str string) (netip.Addr, error) {
+	b, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("malformed boundary %q: %v", str, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipaxis
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/RaduBerinde/axisds"
+	"github.com/RaduBerinde/axisds/regiontree"
+)
+
+func TestPrefix(t *testing.T) {
+	iFmt := axisds.MakeIntervalFormatter(MakeFormatter())
+	eFmt := axisds.MakeEndpointIntervalFormatter(MakeFormatter())
+	for _, tc := range []struct {
+		prefix   string
+		rng      string
+		interval string
+	}{
+		{"10.1.2.0/24", "[10.1.2.0, 10.1.3.0)", "[10.1.2.0, 10.1.2.255]"},
+		{"10.1.2.3/20", "[10.1.0.0, 10.1.16.0)", "[10.1.0.0, 10.1.15.255]"},
+		{"10.1.2.3/32", "[10.1.2.3, 10.1.2.4)", "[10.1.2.3, 10.1.2.3]"},
+		{"0.0.0.0/0", "", "[0.0.0.0, 255.255.255.255]"},
+		{"255.0.0.0/8", "", "[255.0.0.0, 255.255.255.255]"},
+		{"2001:db8::/32", "[2001:db8::, 2001:db9::)", "[2001:db8::, 2001:db8:ffff:ffff:ffff:ffff:ffff:ffff]"},
+	} {
+		p := netip.MustParsePrefix(tc.prefix)
+		start, end, ok := PrefixRange(p)
+		if ok != (tc.rng != "") || (ok && iFmt(start, end) != tc.rng) {
+			t.Errorf("%s: PrefixRange returned %s (ok=%t)", tc.prefix, iFmt(start, end), ok)
+		}
+		if res := eFmt(PrefixInterval(p)); res != tc.interval {
+			t.Errorf("%s: PrefixInterval returned %s", tc.prefix, res)
+		}
+	}
+}
+
+func TestIPAxis(t *testing.T) {
+	rt := Make[bool](func(a, b bool) bool { return a == b })
+	p := MakeParser()
+	set := func(interval string) {
+		start, end := axisds.MustParseInterval(p, interval)
+		rt.Set(start, end, true)
+	}
+	set("[10.0.0.0, 10.1.0.0)")
+	start, end, _ := PrefixRange(netip.MustParsePrefix("10.0.128.0/17"))
+	rt.Delete(start, end)
+	set("[::1, ::2)")
+	expected := "[10.0.0.0, 10.0.128.0) = true\n[::1, ::2) = true\n"
+	if res := rt.String(axisds.MakeIntervalFormatter(MakeFormatter())); res != expected {
+		t.Fatalf("incorrect tree:\n%s", res)
+	}
+
+	et := regiontree.MakeEndpointT[netip.Addr, int](Compare, func(a, b int) bool { return a == b })
+	s, e := PrefixInterval(netip.MustParsePrefix("255.255.255.0/24"))
+	et.Tree().Set(s, e, 1)
+	if !et.Overlaps(netip.MustParseAddr("255.255.255.255"), true, netip.MustParseAddr("255.255.255.255"), true) {
+		t.Fatalf("last address not covered")
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// jsonRegion is the JSON representation of a region.
+type jsonRegion[B Boundary, P Property] struct {
+	Start B `json:"start"`
+	End   B `json:"end"`
+	Prop  P `json:"prop"`
+}
+
+// MarshalJSON implements json.Marshaler. The tree is encoded as an array of
+// {"start", "end", "prop"} objects, one for each region with non-zero
+// property (as emitted by EnumerateAll). The boundaries and properties are
+// encoded using encoding/json.
+func (t T[B, P]) MarshalJSON() ([]byte, error) {
+	regions := []jsonRegion[B, P]{}
+	t.EnumerateAll(func(start, end B, prop P) bool {
+		regions = append(regions, jsonRegion[B, P]{Start: start, End: end, Prop: prop})
+		return true
+	})
+	return json.Marshal(regions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The tree must be created with
+// Make beforehand; any existing regions are removed.
+func (t *T[B, P]) UnmarshalJSON(data []byte) error {
+	defer t.endOp(t.startOp())
+	if t.tree == nil {
+		return errors.New("regiontree: UnmarshalJSON called on uninitialized tree")
+	}
+	var regions []jsonRegion[B, P]
+	if err := json.Unmarshal(data, &regions); err != nil {
+		return err
+	}
+	t.Reset()
+	for _, r := range regions {
+		t.Update(r.Start, r.End, func(P) P { return r.Prop })
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	rt := Make[int, string](cmp.Compare[int], func(a, b string) bool { return a == b })
+	data, err := json.Marshal(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[]" {
+		t.Fatalf("unexpected encoding %s", data)
+	}
+
+	rt.Update(1, 5, func(string) string { return "a" })
+	rt.Update(3, 8, func(string) string { return "b" })
+	rt.Update(10, 12, func(string) string { return "c" })
+	data, err = json.Marshal(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `[{"start":1,"end":3,"prop":"a"},{"start":3,"end":8,"prop":"b"},{"start":10,"end":12,"prop":"c"}]`
+	if string(data) != expected {
+		t.Fatalf("expected %s, got %s", expected, data)
+	}
+
+	rt2 := Make[int, string](cmp.Compare[int], func(a, b string) bool { return a == b })
+	rt2.Update(0, 100, func(string) string { return "x" })
+	if err := json.Unmarshal(data, &rt2); err != nil {
+		t.Fatal(err)
+	}
+	rt2.CheckInvariants()
+	if !rt.Equal(&rt2) {
+		t.Fatalf("roundtrip failed:\n%s", rt2.String(intervalFmt))
+	}
+
+	var uninitialized T[int, string]
+	if err := json.Unmarshal(data, &uninitialized); err == nil {
+		t.Fatal("expected error")
+	}
+}
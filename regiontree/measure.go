@@ -0,0 +1,168 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+// MeasureFn returns the length (or any other additive measure) of the interval
+// [start, end).
+type MeasureFn[B Boundary] func(start, end B) float64
+
+// CoveredLength returns the total measure of the regions with non-zero
+// property within [start, end).
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) CoveredLength(start, end B, measure MeasureFn[B]) float64 {
+	t = t.atNow()
+	var total float64
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		total += measure(rStart, rEnd)
+		return true
+	})
+	return total
+}
+
+// MeasureWhere returns the total measure of the regions within [start, end)
+// with non-zero property that satisfies the given predicate.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) MeasureWhere(start, end B, pred func(prop P) bool, measure MeasureFn[B]) float64 {
+	t = t.atNow()
+	var total float64
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		if pred(prop) {
+			total += measure(rStart, rEnd)
+		}
+		return true
+	})
+	return total
+}
+
+// CoverageFraction returns the fraction (between 0 and 1) of the measure of
+// [start, end) that is covered by regions with non-zero property. Returns 0 if
+// the range is empty (or has zero measure).
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) CoverageFraction(start, end B, measure MeasureFn[B]) float64 {
+	t = t.atNow()
+	if t.cmp(start, end) >= 0 {
+		return 0
+	}
+	total := measure(start, end)
+	if total <= 0 {
+		return 0
+	}
+	return min(t.CoveredLength(start, end, measure)/total, 1)
+}
+
+// RegionAtCoveredOffset is the inverse of CoveredLength: it returns the region
+// (with non-zero property) which contains the given offset, where the offset
+// is measured in covered length from start (only regions with non-zero
+// property contribute). Also returns the offset within the region. Regions are
+// truncated to start.
+//
+// Returns ok=false if the offset is beyond the total covered length after
+// start.
+//
+// For example, this can be used to divide the covered space into chunks with
+// equal covered length.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions
+// between start and the resulting region.
+func (t *T[B, P]) RegionAtCoveredOffset(
+	start B, offset float64, measure MeasureFn[B],
+) (region Region[B, P], within float64, ok bool) {
+	t = t.atNow()
+	_, end, ok := t.Bounds()
+	if !ok {
+		return region, 0, false
+	}
+	ok = false
+	var sum float64
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		m := measure(rStart, rEnd)
+		if offset < sum+m {
+			region = Region[B, P]{Start: rStart, End: rEnd, Prop: prop}
+			within = offset - sum
+			ok = true
+			return false
+		}
+		sum += m
+		return true
+	})
+	return region, within, ok
+}
+
+// Histogram returns the total measure of the regions with non-zero property
+// within [start, end), grouped by bucket (as determined by the bucket
+// function).
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) Histogram(
+	start, end B, bucket func(P) int, measure MeasureFn[B],
+) map[int]float64 {
+	t = t.atNow()
+	h := make(map[int]float64)
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		h[bucket(prop)] += measure(rStart, rEnd)
+		return true
+	})
+	return h
+}
+
+// QuantileBoundary returns the boundary b below which fraction q of the total
+// measure of the regions with non-zero property lies. The region that contains
+// the quantile is found by measuring the regions in order; the boundary within
+// that region is obtained with the interpolate function, which must return the
+// boundary at the given fraction of the measure of [start, end) (the inverse of
+// the measure function within the region).
+//
+// Returns ok=false if the tree is empty. A q <= 0 returns the start of the
+// first region; q >= 1 returns the end of the last region.
+//
+// The runtime complexity is O(N).
+func (t *T[B, P]) QuantileBoundary(
+	q float64, measure MeasureFn[B], interpolate func(start, end B, frac float64) B,
+) (b B, ok bool) {
+	t = t.atNow()
+	var total float64
+	t.EnumerateAll(func(start, end B, prop P) bool {
+		if !ok {
+			// Start of the first region (for q <= 0).
+			b = start
+		}
+		total += measure(start, end)
+		ok = true
+		return true
+	})
+	if !ok || q <= 0 {
+		return b, ok
+	}
+	target := q * total
+	var sum float64
+	t.EnumerateAll(func(start, end B, prop P) bool {
+		m := measure(start, end)
+		if q < 1 && m > 0 && sum+m > target {
+			b = interpolate(start, end, (target-sum)/m)
+			return false
+		}
+		b = end
+		sum += m
+		return true
+	})
+	return b, true
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func intMeasure(start, end int) float64 {
+	return float64(end - start)
+}
+
+func TestCoveredLength(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		for i := 0; i < 10; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			expected := 0
+			for j := a; j < b; j++ {
+				if n.values[j] != 0 {
+					expected++
+				}
+			}
+			if actual := rt.CoveredLength(a, b, intMeasure); actual != float64(expected) {
+				t.Fatalf("CoveredLength(%d, %d) = %v instead of %d\nseed: %d", a, b, actual, expected, seed)
+			}
+			expectedWhere := 0
+			for j := a; j < b; j++ {
+				if n.values[j] > 1 {
+					expectedWhere++
+				}
+			}
+			if actual := rt.MeasureWhere(a, b, func(p int) bool { return p > 1 }, intMeasure); actual != float64(expectedWhere) {
+				t.Fatalf("MeasureWhere(%d, %d) = %v instead of %d\nseed: %d", a, b, actual, expectedWhere, seed)
+			}
+			expectedFraction := 0.0
+			if a < b {
+				expectedFraction = float64(expected) / float64(b-a)
+			}
+			if actual := rt.CoverageFraction(a, b, intMeasure); actual != expectedFraction {
+				t.Fatalf("CoverageFraction(%d, %d) = %v instead of %v\nseed: %d", a, b, actual, expectedFraction, seed)
+			}
+		}
+	}
+}
+
+func TestRegionAtCoveredOffset(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		start := rng.IntN(valRange)
+		offset := rng.IntN(valRange)
+
+		var expected Region[int, int]
+		var expectedWithin float64
+		expectedOk := false
+		covered := 0
+		n.Enumerate(start, maxRange, func(rStart, rEnd, val int) {
+			if !expectedOk && offset < covered+rEnd-rStart {
+				expected = Region[int, int]{Start: rStart, End: rEnd, Prop: val}
+				expectedWithin = float64(offset - covered)
+				expectedOk = true
+			}
+			covered += rEnd - rStart
+		})
+		r, within, ok := rt.RegionAtCoveredOffset(start, float64(offset), intMeasure)
+		if r != expected || within != expectedWithin || ok != expectedOk {
+			t.Fatalf("RegionAtCoveredOffset(%d, %d) = %v, %v, %t instead of %v, %v, %t\nseed: %d",
+				start, offset, r, within, ok, expected, expectedWithin, expectedOk, seed)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		a, b := rng.IntN(valRange), rng.IntN(valRange)
+		bucket := func(p int) int { return p % 3 }
+		expected := make(map[int]float64)
+		for i := a; i < b; i++ {
+			if n.values[i] != 0 {
+				expected[bucket(n.values[i])]++
+			}
+		}
+		if actual := rt.Histogram(a, b, bucket, intMeasure); !maps.Equal(actual, expected) {
+			t.Fatalf("Histogram(%d, %d) = %v instead of %v\nseed: %d", a, b, actual, expected, seed)
+		}
+	}
+}
+
+func TestQuantileBoundary(t *testing.T) {
+	interpolate := func(start, end int, frac float64) int {
+		return start + int(math.Round(frac*float64(end-start)))
+	}
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		// below returns the covered length below b.
+		below := func(b int) int {
+			res := 0
+			for i := 0; i < b; i++ {
+				if n.values[i] != 0 {
+					res++
+				}
+			}
+			return res
+		}
+		total := below(maxRange)
+		for _, q := range []float64{0, 0.1, 0.25, 0.5, 0.75, 0.99, 1, rng.Float64()} {
+			b, ok := rt.QuantileBoundary(q, intMeasure, interpolate)
+			if !ok {
+				if total != 0 {
+					t.Fatalf("QuantileBoundary(%v) not ok\nseed: %d", q, seed)
+				}
+				continue
+			}
+			// The integer interpolation rounds, so the covered length below b can
+			// differ from the target by up to 1/2.
+			if target := q * float64(total); math.Abs(float64(below(b))-target) > 0.5+1e-9 {
+				t.Fatalf("QuantileBoundary(%v) = %d with %d below, target %v\nseed: %d", q, b, below(b), target, seed)
+			}
+			// The boundary must be inside a region or at the end of one.
+			if b > 0 && n.values[b] == 0 && n.values[b-1] == 0 {
+				t.Fatalf("QuantileBoundary(%v) = %d is outside the regions\nseed: %d", q, b, seed)
+			}
+		}
+	}
+	// The quantile is interpolated within a single wide region.
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	rt.Set(100, 1100, 1)
+	for _, tc := range []struct {
+		q        float64
+		expected int
+	}{{0, 100}, {0.25, 350}, {0.5, 600}, {0.999, 1099}, {1, 1100}} {
+		if b, _ := rt.QuantileBoundary(tc.q, intMeasure, interpolate); b != tc.expected {
+			t.Fatalf("QuantileBoundary(%v) = %d instead of %d", tc.q, b, tc.expected)
+		}
+	}
+}
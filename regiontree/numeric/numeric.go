@@ -0,0 +1,68 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package numeric contains helpers for region trees with numeric properties.
+package numeric
+
+import (
+	"github.com/RaduBerinde/axisds"
+	"github.com/RaduBerinde/axisds/regiontree"
+)
+
+// Number is a constraint for numeric property types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Make creates a new region tree with numeric properties (compared with ==).
+func Make[B regiontree.Boundary, N Number](cmp axisds.CompareFn[B]) regiontree.T[B, N] {
+	return regiontree.Make[B, N](cmp, func(a, b N) bool { return a == b })
+}
+
+// Add adds delta to the property of every point in [start, end). Returns true
+// if any property changed.
+func Add[B regiontree.Boundary, N Number](t *regiontree.T[B, N], start, end B, delta N) bool {
+	return t.Update(start, end, func(p N) N { return p + delta })
+}
+
+// SetMax sets the property of every point in [start, end) to the maximum
+// between its current property and v. Returns true if any property changed.
+func SetMax[B regiontree.Boundary, N Number](t *regiontree.T[B, N], start, end B, v N) bool {
+	return t.Update(start, end, func(p N) N { return max(p, v) })
+}
+
+// SetMin sets the property of every point in [start, end) to the minimum
+// between its current property and v. Note that points outside of any region
+// have the zero property. Returns true if any property changed.
+func SetMin[B regiontree.Boundary, N Number](t *regiontree.T[B, N], start, end B, v N) bool {
+	return t.Update(start, end, func(p N) N { return min(p, v) })
+}
+
+// Sum returns the integral of the property over [start, end): the sum of the
+// property of each region multiplied by the measure of the region.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func Sum[B regiontree.Boundary, N Number](
+	t *regiontree.T[B, N], start, end B, measure regiontree.MeasureFn[B],
+) float64 {
+	var sum float64
+	t.Enumerate(start, end, func(rStart, rEnd B, prop N) bool {
+		sum += float64(prop) * measure(rStart, rEnd)
+		return true
+	})
+	return sum
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package numeric
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestNumeric(t *testing.T) {
+	const valRange = 100
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		rt := Make[int, int](cmp.Compare[int])
+		var naive [valRange]int
+		for op := 0; op < 50; op++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			v := rng.IntN(10) - 3
+			switch rng.IntN(4) {
+			case 0:
+				Add(&rt, a, b, v)
+				for i := a; i < b; i++ {
+					naive[i] += v
+				}
+			case 1:
+				SetMax(&rt, a, b, v)
+				for i := a; i < b; i++ {
+					naive[i] = max(naive[i], v)
+				}
+			case 2:
+				SetMin(&rt, a, b, v)
+				for i := a; i < b; i++ {
+					naive[i] = min(naive[i], v)
+				}
+			case 3:
+				expected := 0
+				for i := a; i < b; i++ {
+					expected += naive[i]
+				}
+				measure := func(start, end int) float64 { return float64(end - start) }
+				if actual := Sum(&rt, a, b, measure); actual != float64(expected) {
+					t.Fatalf("Sum(%d, %d) = %v instead of %d\nseed: %d", a, b, actual, expected, seed)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"time"
+
+	"github.com/RaduBerinde/btreemap"
+)
+
+// Options contains optional settings for a region tree; see MakeWithOptions.
+// The zero value corresponds to the default settings.
+type Options[B Boundary, P Property] struct {
+	// Degree of the internal B-tree. Each node holds between Degree-1 and
+	// 2*Degree-1 boundaries. A larger degree results in a shallower tree, which
+	// can reduce the number of comparisons and cache misses when the tree is
+	// large or when comparisons are expensive; it also makes copy-on-write
+	// after Clone more expensive.
+	//
+	// If zero, the default degree of 8 is used. The degree must be at least 2.
+	Degree int
+
+	// NodePool, if set, is used to allocate the internal B-tree nodes. Nodes
+	// are returned to the pool when the tree is Reset. The pool can be shared
+	// between multiple trees.
+	NodePool *NodePool[B, P]
+
+	// IsZero, if set, determines which properties are "zero" (i.e. equivalent
+	// to the absence of a region). By default, a property is zero if it is equal
+	// to the zero value of P according to the PropertyEqualFn. This is useful
+	// for properties like slices or pointers where the "empty" value can have
+	// multiple representations.
+	//
+	// IsZero must return true for the zero value of P. All zero properties are
+	// considered equal to each other.
+	IsZero func(P) bool
+
+	// IsExpired, if set, determines whether a property has expired. Expired
+	// properties are equivalent to the zero property (this is a more
+	// convenient alternative to an evolving PropertyEqualFn):
+	//   - regions with expired properties never appear in query results (and
+	//     RangeProperty returns the zero property for them);
+	//   - update functions (Update, UpdateIf, ApplyBatch) are passed the zero
+	//     property instead of an expired property;
+	//   - the OnChange hook is never passed an expired property as oldProp.
+	//
+	// Boundaries of expired regions are removed lazily, by EnumerateWithGC,
+	// AnyWithGC and Compact.
+	//
+	// The function is allowed to evolve over time (but not concurrently with a
+	// region tree method), typically by comparing against a low watermark that
+	// only increases. Once a property has expired, it must stay expired
+	// forever.
+	IsExpired func(P) bool
+
+	// Deadline, if set, makes properties expire at a deadline: a property
+	// expires once the clock reaches the deadline returned by the function.
+	// Expired properties are treated as described for IsExpired (the two
+	// options can be combined). Expired regions can also be removed explicitly
+	// with ExpireUpTo.
+	Deadline func(P) time.Time
+
+	// Clock is used with Deadline; if not set, time.Now is used. The clock is
+	// read once at the beginning of each operation, and that time is used for
+	// the entire operation.
+	Clock func() time.Time
+
+	// NormalizeBoundary, if set, maps each boundary to a canonical form (e.g.
+	// lower-casing keys); boundaries with the same canonical form are
+	// considered equal. It is applied to the boundaries passed to all
+	// modifications and to methods that create new trees, like CloneRange (so
+	// that only canonical boundaries are stored), and it is incorporated in the
+	// boundary comparison function (so that queries and operations with other
+	// trees work with non-canonical boundaries as well).
+	//
+	// The function must be idempotent. It is called for every comparison, so it
+	// should be cheap.
+	NormalizeBoundary func(B) B
+
+	// Quantize, if set, is applied to all the boundaries passed to Update,
+	// UpdateWithSpan, UpdateIf, UpdateMasked, Set, Delete, ApplyBatch,
+	// Truncate, Excise, SetLowWatermark, SplitAt, CloneRange and Complement;
+	// for example, it can snap the boundaries to a block grid so that regions
+	// are always aligned. An operation on a range that becomes empty after
+	// quantization has no effect.
+	//
+	// The function must be non-decreasing and idempotent. It is applied after
+	// NormalizeBoundary. Boundaries that come from other trees (e.g. in
+	// Subtract and Clip) are not quantized.
+	Quantize func(B) B
+
+	// MaxBoundaries, if non-zero, bounds the number of boundaries stored in the
+	// tree (which is at least the number of regions). When a modification
+	// causes the tree to exceed this limit, the tree is automatically coarsened
+	// (approximated) by merging adjacent regions using MergeFn, in increasing
+	// order of merge cost, until the number of boundaries is at most 3/4 of the
+	// limit. MaxBoundaries must be at least 2.
+	//
+	// The coarsening is performed at the end of Update, UpdateWithSpan,
+	// UpdateIf, Set, Delete, ApplyBatch and Subtract; it is O(N log N) but its
+	// cost is amortized over multiple modifications.
+	MaxBoundaries int
+
+	// MergeFn is required when MaxBoundaries is set.
+	MergeFn MergeFn[B, P]
+
+	// OnChange, if set, is called whenever the property of a range changes
+	// (i.e. the new property is not equal to the old one), with the old and
+	// the new property. It is called by all the operations that modify the
+	// tree: Update, UpdateWithSpan, UpdateIf, UpdateMasked, Set, Delete,
+	// ApplyBatch, Apply, Subtract, Clip, DeleteWhere, ReplaceWhere, Truncate,
+	// Excise, SetLowWatermark, Reset, Shift, Coarsen (and the automatic
+	// coarsening for MaxBoundaries), ExpireUpTo, UnmarshalJSON, Rollback and
+	// Txn.Commit. A single operation can report multiple adjacent ranges;
+	// Shift reports the removal of all the regions followed by the addition of
+	// the shifted regions.
+	//
+	// The function is called while the operation is in progress; it must not
+	// access the tree.
+	//
+	// Clones (and other trees derived from this tree) do not inherit the hook.
+	OnChange func(start, end B, oldProp, newProp P)
+
+	// OpLog, if set, is called with an entry for every change to the tree: the
+	// entry is a range along with its new property. The entries are reported
+	// for all the operations that modify the tree (the same as OnChange), so
+	// replaying them (in order) with Apply onto a tree that had the same
+	// regions reproduces the changes; this can be used to keep a replica in
+	// sync. Region implements encoding.BinaryMarshaler so entries can be
+	// shipped between processes.
+	//
+	// Clones (and other trees derived from this tree) do not inherit the log.
+	OpLog func(entry Region[B, P])
+
+	// CloneProp, if set, is used to make deep copies of the properties when the
+	// tree is cloned, for properties that contain pointers, slices or maps. By
+	// default, a clone shares the property values with the original tree.
+	//
+	// With CloneProp, Clone (and operations that use it, like Freeze and
+	// SplitAt) and CloneRange copy all the properties, so Clone is O(N log N)
+	// instead of constant time.
+	CloneProp func(P) P
+
+	// Validate enables expensive consistency checks after each Update,
+	// UpdateWithSpan, UpdateIf, Set, Delete, ApplyBatch and Subtract: the
+	// invariants are checked, and the tree is cross-checked against its state
+	// before the operation to verify that nothing outside the requested range
+	// changed (and, where the resulting properties are known, that the range
+	// has the expected properties). A violation causes a panic at the offending
+	// call site.
+	//
+	// The checks are O(N) per operation; this option is intended for tests.
+	Validate bool
+}
+
+// NodePool is a pool of internal B-tree nodes that can be shared by multiple
+// region trees (see Options.NodePool). It is useful when many short-lived
+// trees are created: calling Reset on a tree that is no longer needed returns
+// its nodes to the pool, to be reused by other trees.
+//
+// NodePool is safe for concurrent use.
+type NodePool[B Boundary, P Property] struct {
+	freeList *btreemap.FreeList[B, P]
+}
+
+// NewNodePool creates a new pool which can hold up to size free nodes.
+func NewNodePool[B Boundary, P Property](size int) *NodePool[B, P] {
+	return &NodePool[B, P]{freeList: btreemap.NewFreeList[B, P](size)}
+}
+
+// defaultDegree is the default degree of the internal B-tree.
+const defaultDegree = 8
@@ -0,0 +1,385 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNodePool(t *testing.T) {
+	run := func(opts Options[int, int]) float64 {
+		return testing.AllocsPerRun(10, func() {
+			rt := MakeWithOptions[int, int](cmp.Compare[int], func(a, b int) bool { return a == b }, opts)
+			for i := 0; i < 100; i++ {
+				rt.Set(i*10, i*10+5, i+1)
+			}
+			rt.Reset()
+		})
+	}
+	withoutPool := run(Options[int, int]{Degree: 2})
+	withPool := run(Options[int, int]{Degree: 2, NodePool: NewNodePool[int, int](1000)})
+	if withPool >= withoutPool {
+		t.Fatalf("expected fewer allocations with pool (%v vs %v)", withPool, withoutPool)
+	}
+}
+
+// TestOnChange verifies that a naiveInts maintained purely through the
+// OnChange hook stays in sync with the tree.
+func TestOnChange(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		var mirror naiveInts
+		var log []string
+		opts := Options[int, int]{
+			OnChange: func(start, end int, oldProp, newProp int) {
+				log = append(log, fmt.Sprintf("[%d, %d) %d -> %d", start, end, oldProp, newProp))
+				if start >= end {
+					t.Fatalf("invalid range; seed: %d\n%v", seed, log)
+				}
+				for i := start; i < end; i++ {
+					if mirror.values[i] != oldProp {
+						t.Fatalf("incorrect old property at %d; seed: %d\n%v", i, seed, log)
+					}
+					mirror.values[i] = newProp
+				}
+			},
+		}
+		rt := MakeWithOptions[int, int](cmp.Compare[int], func(a, b int) bool { return a == b }, opts)
+		for i, numOps := 0, rng.IntN(50); i < numOps; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			value := rng.IntN(5)
+			switch rng.IntN(9) {
+			case 0:
+				rt.Update(a, b, func(p int) int { return p + value - 2 })
+			case 1:
+				rt.Set(a, b, value)
+			case 2:
+				rt.Delete(a, b)
+			case 3:
+				rt.UpdateIf(a, b, func(p int) bool { return p > 0 }, func(p int) int { return value })
+			case 4:
+				other, _ := randomTree(rng, valRange)
+				rt.Subtract(&other)
+			case 5:
+				rt.ApplyBatch([]RangeUpdate[int, int]{
+					{Start: a, End: b, UpdateProp: func(p int) int { return p + 1 }},
+					{Start: rng.IntN(valRange), End: valRange, UpdateProp: func(p int) int { return value }},
+				})
+			case 6:
+				if rng.IntN(5) == 0 {
+					rt.Reset()
+				} else {
+					rt.Truncate(a, b)
+				}
+			case 7:
+				if rng.IntN(2) == 0 {
+					rt.DeleteWhere(func(p int) bool { return p == value })
+				} else {
+					rt.ReplaceWhere(func(p int) bool { return p == value }, func(p int) int { return p + 1 })
+				}
+			case 8:
+				if _, end, ok := rt.Bounds(); !ok || end+value < maxRange {
+					rt.Shift(func(b int) int { return b + value + 1 })
+				}
+			}
+			checkEqual(t, &rt, &mirror, fmt.Sprintf("seed: %d\n%v", seed, log))
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// A broken equality function which doesn't consider 7 equal to itself.
+	propEq := func(a, b int) bool { return a == b && a != 7 }
+	rt := MakeWithOptions[int, int](cmp.Compare[int], propEq, Options[int, int]{Validate: true})
+	rt.Set(1, 10, 1)
+	rt.Update(5, 15, func(p int) int { return p + 1 })
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	rt.Set(2, 3, 7)
+}
+
+func TestIsZero(t *testing.T) {
+	rt := MakeWithOptions[int, []int](
+		cmp.Compare[int],
+		slices.Equal[[]int],
+		Options[int, []int]{IsZero: func(p []int) bool { return len(p) == 0 }},
+	)
+	rt.Set(0, 10, []int{})
+	if !rt.IsEmpty() {
+		t.Fatalf("expected empty tree:\n%s", rt.String(intervalFmt))
+	}
+	rt.Set(0, 10, []int{1})
+	rt.Set(5, 15, []int{})
+	rt.Update(20, 30, func(p []int) []int { return append(p, 2) })
+	rt.Update(25, 40, func(p []int) []int { return p[:0] })
+	rt.CheckInvariants()
+	expected := "[0, 5) = [1]\n[20, 25) = [2]\n"
+	if actual := rt.String(intervalFmt); actual != expected {
+		t.Fatalf("expected:\n%sgot:\n%s", expected, actual)
+	}
+}
+
+// TestOpLog verifies that replaying the operation log onto a replica keeps it
+// in sync with the tree, after every type of modification.
+func TestOpLog(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		var log []Region[int, int]
+		opts := Options[int, int]{
+			OpLog: func(entry Region[int, int]) { log = append(log, entry) },
+		}
+		if rng.IntN(2) == 0 {
+			opts.MaxBoundaries = rng.IntN(20) + 2
+			opts.MergeFn = maxMerge
+		}
+		propEq := func(a, b int) bool { return a == b }
+		rt := MakeWithOptions[int, int](cmp.Compare[int], propEq, opts)
+		replica := Make[int, int](cmp.Compare[int], propEq)
+		var ops []string
+		for i, numOps := 0, rng.IntN(50); i < numOps; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			value := rng.IntN(5)
+			op := rng.IntN(16)
+			switch op {
+			case 0:
+				rt.Update(a, b, func(p int) int { return p + value })
+			case 1:
+				rt.Set(a, b, value)
+			case 2:
+				rt.Delete(a, b)
+			case 3:
+				rt.UpdateIf(a, b, func(p int) bool { return p > 1 }, func(p int) int { return value })
+			case 4:
+				rt.ApplyBatch([]RangeUpdate[int, int]{
+					{Start: a, End: b, UpdateProp: func(p int) int { return p + 1 }},
+					{Start: rng.IntN(valRange), End: valRange, UpdateProp: func(p int) int { return value }},
+				})
+			case 5:
+				other, _ := randomTree(rng, valRange)
+				rt.Subtract(&other)
+			case 6:
+				other, _ := randomTree(rng, valRange)
+				Clip(&rt, &other)
+			case 7:
+				other, _ := randomTree(rng, valRange)
+				UpdateMasked(&rt, &other, a, b, func(p int) int { return p + 1 })
+			case 8:
+				rt.DeleteWhere(func(p int) bool { return p == value })
+			case 9:
+				rt.ReplaceWhere(func(p int) bool { return p == value }, func(p int) int { return p + 1 })
+			case 10:
+				rt.Truncate(a, b)
+			case 11:
+				rt.Excise(a, b)
+			case 12:
+				if rng.IntN(3) == 0 {
+					rt.Reset()
+				} else {
+					rt.SetLowWatermark(a)
+				}
+			case 13:
+				if _, end, ok := rt.Bounds(); !ok || end+value < maxRange {
+					rt.Shift(func(b int) int { return b + value + 1 })
+				}
+			case 14:
+				rt.Coarsen(float64(value), intMeasure, func(a, b int) int { return max(a, b) })
+			case 15:
+				if rng.IntN(2) == 0 {
+					rt.Checkpoint()
+					rt.Set(a, b, value)
+					rt.Rollback()
+				} else {
+					txn := rt.Begin()
+					txn.Set(a, b, value)
+					txn.Commit()
+				}
+			}
+			ops = append(ops, fmt.Sprint(op))
+			// Clones don't inherit the log.
+			c := rt.Clone()
+			c.Set(0, valRange, 1)
+			replica.Apply(log)
+			log = log[:0]
+			if !rt.Equal(&replica) {
+				t.Fatalf("replica out of sync; seed: %d\nops: %v\n%s\n%s",
+					seed, ops, rt.String(intervalFmt), replica.String(intervalFmt))
+			}
+		}
+	}
+}
+
+func TestCloneProp(t *testing.T) {
+	rt := MakeWithOptions[int, []int](
+		cmp.Compare[int],
+		slices.Equal[[]int],
+		Options[int, []int]{
+			IsZero:    func(p []int) bool { return len(p) == 0 },
+			CloneProp: slices.Clone[[]int],
+		},
+	)
+	rt.Set(1, 10, []int{1, 2})
+	rt.Set(20, 30, []int{3})
+	c := rt.Clone()
+	r := rt.CloneRange(5, 25)
+	// Modify the properties of the original in place.
+	rt.EnumerateAll(func(start, end int, prop []int) bool {
+		prop[0] = 100
+		return true
+	})
+	propFmt := func(p []int) string { return fmt.Sprint(p) }
+	if res, expected := c.StringWithPropFormatter(intervalFmt, propFmt), "[1, 10) = [1 2]\n[20, 30) = [3]\n"; res != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, res)
+	}
+	if res, expected := r.StringWithPropFormatter(intervalFmt, propFmt), "[5, 10) = [1 2]\n[20, 25) = [3]\n"; res != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, res)
+	}
+	c.CheckInvariants()
+	r.CheckInvariants()
+}
+
+func TestQuantize(t *testing.T) {
+	const grid = 8
+	quantize := func(b int) int { return b / grid * grid }
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		opts := Options[int, int]{Quantize: quantize}
+		rt := MakeWithOptions[int, int](cmp.Compare[int], func(a, b int) bool { return a == b }, opts)
+		var n naiveInts
+		for i, numOps := 0, rng.IntN(50); i < numOps; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			qa, qb := quantize(a), quantize(b)
+			value := rng.IntN(5)
+			switch rng.IntN(5) {
+			case 0:
+				rt.Update(a, b, func(p int) int { return p + value })
+				n.Add(qa, qb, value)
+			case 1:
+				rt.Set(a, b, value)
+				n.Set(qa, qb, value)
+			case 2:
+				rt.Delete(a, b)
+				n.Set(qa, qb, 0)
+			case 3:
+				rt.ApplyBatch([]RangeUpdate[int, int]{{Start: a, End: b, UpdateProp: func(p int) int { return value }}})
+				n.Set(qa, qb, value)
+			case 4:
+				rt.Truncate(a, b)
+				if qa < qb {
+					n.Set(0, qa, 0)
+					n.Set(qb, maxRange, 0)
+				} else {
+					n.Set(0, maxRange, 0)
+				}
+			}
+			context := fmt.Sprintf("seed: %d", seed)
+			checkEqual(t, &rt, &n, context)
+			rt.EnumerateAll(func(start, end, prop int) bool {
+				if start%grid != 0 || end%grid != 0 {
+					t.Fatalf("unaligned region [%d, %d)\n%s", start, end, context)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// TestQuantizeRestore verifies that Rollback and Apply don't quantize the
+// boundaries that were produced (off the grid) by Subtract.
+func TestQuantizeRestore(t *testing.T) {
+	quantize := func(b int) int { return b / 10 * 10 }
+	eq := func(a, b int) bool { return a == b }
+	var log []Region[int, int]
+	rt := MakeWithOptions[int, int](cmp.Compare[int], eq, Options[int, int]{
+		Quantize: quantize,
+		OpLog:    func(e Region[int, int]) { log = append(log, e) },
+	})
+	rt.Set(10, 20, 1)
+	other := Make[int, int](cmp.Compare[int], eq)
+	other.Set(13, 17, 1)
+	rt.Subtract(&other)
+	const expected = "[10, 13) = 1\n[17, 20) = 1\n"
+	if actual := rt.String(intervalFmt); actual != expected {
+		t.Fatalf("after Subtract:\n%sexpected:\n%s", actual, expected)
+	}
+
+	rt.Checkpoint()
+	rt.Set(10, 20, 5)
+	rt.Rollback()
+	if actual := rt.String(intervalFmt); actual != expected {
+		t.Fatalf("after Rollback:\n%sexpected:\n%s", actual, expected)
+	}
+
+	replica := MakeWithOptions[int, int](cmp.Compare[int], eq, Options[int, int]{Quantize: quantize})
+	replica.Apply(log)
+	if actual := replica.String(intervalFmt); actual != expected {
+		t.Fatalf("after Apply:\n%sexpected:\n%s", actual, expected)
+	}
+}
+
+func TestNormalizeBoundary(t *testing.T) {
+	rt := MakeWithOptions[string, int](
+		cmp.Compare[string],
+		func(a, b int) bool { return a == b },
+		Options[string, int]{NormalizeBoundary: strings.ToLower},
+	)
+	rt.Set("A", "c", 1)
+	rt.Set("b", "C", 2)
+	rt.Update("c", "E", func(p int) int { return p + 2 })
+	rt.Update("D", "e", func(p int) int { return p + 1 })
+	var regions []string
+	rt.EnumerateAll(func(start, end string, prop int) bool {
+		regions = append(regions, fmt.Sprintf("[%s, %s) = %d", start, end, prop))
+		return true
+	})
+	if expected := []string{"[a, b) = 1", "[b, d) = 2", "[d, e) = 3"}; !slices.Equal(regions, expected) {
+		t.Fatalf("expected %v, got %v", expected, regions)
+	}
+	if prop, ok := rt.RangeProperty("C", "D"); !ok || prop != 2 {
+		t.Fatalf("incorrect RangeProperty: %d %t", prop, ok)
+	}
+	if rt.Overlaps("E", "Z") {
+		t.Fatalf("expected no overlap")
+	}
+	c := rt.CloneRange("BB", "D")
+	c.Set("C", "Z", 5)
+	if res, expected := fmt.Sprint(c.FirstN(10)), "[{bb c 2} {c z 5}]"; res != expected {
+		t.Fatalf("expected %s, got %s", expected, res)
+	}
+	rt.CheckInvariants()
+	c.CheckInvariants()
+}
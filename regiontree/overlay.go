@@ -0,0 +1,115 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+// Overlay is a read-only view of a stack of region trees, where the property at
+// any point is the property of the top-most tree which has a non-zero property
+// at that point. For example, the bottom layer can be the base state and the
+// upper layers uncommitted changes.
+//
+// The overlay does not materialize a merged tree; queries run across all the
+// layers. The layers can be modified between queries (but not concurrently
+// with a query).
+//
+// All layers must use the same boundary comparison function and equivalent
+// property equality functions.
+type Overlay[B Boundary, P Property] struct {
+	// layers are in increasing order of precedence.
+	layers []*T[B, P]
+}
+
+// MakeOverlay creates an overlay of the given trees. The layers are in
+// increasing order of precedence: the first layer is the bottom and the last
+// layer is the top.
+func MakeOverlay[B Boundary, P Property](layers ...*T[B, P]) Overlay[B, P] {
+	return Overlay[B, P]{layers: layers}
+}
+
+// PropertyAt returns the property at the given boundary, i.e. the property of
+// the top-most layer which has a non-zero property at b.
+//
+// The runtime complexity is O(L log N) where L is the number of layers.
+func (o *Overlay[B, P]) PropertyAt(b B) P {
+	var zeroProp P
+	for i := len(o.layers) - 1; i >= 0; i-- {
+		l := o.layers[i].atNow()
+		if _, prop := l.endBoundaryInfo(b); !l.propEq(prop, zeroProp) {
+			return prop
+		}
+	}
+	return zeroProp
+}
+
+// Enumerate emits all the non-zero regions of the overlay within [start, end),
+// in order. Neighboring regions with equal properties are merged. Regions
+// that span beyond the range are truncated.
+//
+// Enumerate stops once emit() returns false.
+//
+// The runtime complexity is O(L log N + K L) where L is the number of layers
+// and K is the total number of boundaries (across all layers) in the range.
+func (o *Overlay[B, P]) Enumerate(start, end B, emit func(start, end B, prop P) bool) {
+	if len(o.layers) == 0 {
+		return
+	}
+	layers := treesAtNow(o.layers)
+	cmp, propEq := layers[0].cmp, layers[0].propEq
+	var zeroProp P
+	var cur struct {
+		start, end B
+		prop       P
+		set        bool
+	}
+	stopped := false
+	// ZipEnumerateN skips the fragments where all layers have zero property, so
+	// each fragment has a top-most layer with a non-zero property.
+	ZipEnumerateN(layers, start, end, func(fStart, fEnd B, props []P) bool {
+		var prop P
+		for i := len(props) - 1; i >= 0; i-- {
+			if !layers[i].propEq(props[i], zeroProp) {
+				prop = props[i]
+				break
+			}
+		}
+		if cur.set {
+			if cmp(cur.end, fStart) == 0 && propEq(cur.prop, prop) {
+				cur.end = fEnd
+				return true
+			}
+			if !emit(cur.start, cur.end, cur.prop) {
+				stopped = true
+				return false
+			}
+		}
+		cur.start, cur.end, cur.prop, cur.set = fStart, fEnd, prop, true
+		return true
+	})
+	if cur.set && !stopped {
+		emit(cur.start, cur.end, cur.prop)
+	}
+}
+
+// Any returns true if the overlay has a region within [start, end) for which
+// fn returns true. Regions with zero property are ignored.
+//
+// The runtime complexity is the same as that of Enumerate.
+func (o *Overlay[B, P]) Any(start, end B, fn func(prop P) bool) bool {
+	found := false
+	o.Enumerate(start, end, func(_, _ B, prop P) bool {
+		found = fn(prop)
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+
+		var layers []*T[int, int]
+		var n naiveInts
+		for i, numLayers := 0, rng.IntN(4); i < numLayers; i++ {
+			rt, ln := randomTree(rng, valRange)
+			layers = append(layers, &rt)
+			for j, v := range ln.values {
+				if v != 0 {
+					n.values[j] = v
+				}
+			}
+		}
+		o := MakeOverlay(layers...)
+		context := fmt.Sprintf("seed: %d", seed)
+
+		for i := 0; i < 10; i++ {
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			var actual, expected [][3]int
+			o.Enumerate(a, b, func(start, end, prop int) bool {
+				actual = append(actual, [3]int{start, end, prop})
+				return true
+			})
+			n.Enumerate(a, b, func(start, end, prop int) {
+				expected = append(expected, [3]int{start, end, prop})
+			})
+			if !slices.Equal(actual, expected) {
+				t.Fatalf("Enumerate(%d, %d) = %v instead of %v\n%s", a, b, actual, expected, context)
+			}
+
+			val := 1 + rng.IntN(4)
+			if actual, expected := o.Any(a, b, func(p int) bool { return p == val }),
+				n.Any(a, b, func(p int) bool { return p == val }); actual != expected {
+				t.Fatalf("Any(%d, %d, =%d) = %t instead of %t\n%s", a, b, val, actual, expected, context)
+			}
+
+			if actual, expected := o.PropertyAt(a), n.values[a]; actual != expected {
+				t.Fatalf("PropertyAt(%d) = %d instead of %d\n%s", a, actual, expected, context)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"sync"
+
+	"github.com/RaduBerinde/axisds"
+)
+
+// Persistent is an immutable region tree: every modification returns a new
+// Persistent which shares structure with the old one (using copy-on-write), and
+// the old value remains valid and unchanged. A Persistent must be created with
+// MakePersistent.
+//
+// All methods (including modifications) can be used concurrently from
+// multiple goroutines without synchronization. The read-only methods are the
+// same as those of Frozen.
+type Persistent[B Boundary, P Property] struct {
+	Frozen[B, P]
+	// mu protects the copy-on-write state of the B-tree, which is updated when
+	// the tree is cloned.
+	mu *sync.Mutex
+}
+
+// MakePersistent creates a new empty persistent region tree with the given
+// boundary and property comparison functions.
+func MakePersistent[B Boundary, P Property](
+	cmp axisds.CompareFn[B], propEq PropertyEqualFn[P],
+) Persistent[B, P] {
+	return Persistent[B, P]{
+		Frozen: Frozen[B, P]{t: Make(cmp, propEq)},
+		mu:     &sync.Mutex{},
+	}
+}
+
+// Update returns a new tree with the property updated for the given range. See
+// T.Update.
+func (p Persistent[B, P]) Update(start, end B, updateProp func(p P) P) Persistent[B, P] {
+	return p.with(func(t *T[B, P]) {
+		t.Update(start, end, updateProp)
+	})
+}
+
+// Set returns a new tree with the property set for the given range. See T.Set.
+func (p Persistent[B, P]) Set(start, end B, prop P) Persistent[B, P] {
+	return p.with(func(t *T[B, P]) {
+		t.Set(start, end, prop)
+	})
+}
+
+// Delete returns a new tree with the given range removed. See T.Delete.
+func (p Persistent[B, P]) Delete(start, end B) Persistent[B, P] {
+	return p.with(func(t *T[B, P]) {
+		t.Delete(start, end)
+	})
+}
+
+// with returns a new tree obtained by applying the given modification to a
+// clone of the tree.
+func (p Persistent[B, P]) with(modify func(t *T[B, P])) Persistent[B, P] {
+	p.mu.Lock()
+	t := p.t.Clone()
+	p.mu.Unlock()
+	modify(&t)
+	return Persistent[B, P]{
+		Frozen: Frozen[B, P]{t: t},
+		mu:     &sync.Mutex{},
+	}
+}
+
+// Thaw returns a new (modifiable) tree with the same regions. See
+// Frozen.Thaw.
+func (p Persistent[B, P]) Thaw() T[B, P] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.t.Clone()
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+func TestPersistent(t *testing.T) {
+	seed := rand.Uint64()
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	// Build a sequence of versions, remembering the expected state of each.
+	versions := []Persistent[int, int]{
+		MakePersistent[int, int](cmp.Compare[int], func(a, b int) bool { return a == b }),
+	}
+	expected := []naiveInts{{}}
+	for i := 0; i < 100; i++ {
+		p, n := versions[len(versions)-1], expected[len(expected)-1]
+		a, b := rng.IntN(100), rng.IntN(100)
+		if a > b {
+			a, b = b, a
+		}
+		value := rng.IntN(5)
+		switch rng.IntN(3) {
+		case 0:
+			p = p.Update(a, b, func(p int) int { return p + value })
+			n.Add(a, b, value)
+		case 1:
+			p = p.Set(a, b, value)
+			n.Set(a, b, value)
+		case 2:
+			p = p.Delete(a, b)
+			n.Set(a, b, 0)
+		}
+		versions = append(versions, p)
+		expected = append(expected, n)
+	}
+
+	strs := make([]string, len(versions))
+	for i := range versions {
+		rt := versions[i].Thaw()
+		checkEqual(t, &rt, &expected[i], fmt.Sprintf("version %d\nseed: %d", i, seed))
+		strs[i] = versions[i].String(intervalFmt)
+	}
+
+	// Concurrently read all versions and derive new versions from them; the
+	// old versions must not change.
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range versions {
+				p := versions[i].Set(0, 50, 100+g)
+				if _, _, prop, _ := p.SeekNonZero(0); prop != 100+g {
+					t.Errorf("unexpected property %d", prop)
+				}
+				if str := versions[i].String(intervalFmt); str != strs[i] {
+					t.Errorf("version %d changed:\n%sexpected:\n%s", i, str, strs[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -16,7 +16,10 @@ package regiontree
 
 import (
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/RaduBerinde/axisds"
 	"github.com/RaduBerinde/btreemap"
@@ -47,31 +50,212 @@ type PropertyEqualFn[P Property] func(a, b P) bool
 //
 // T supports lazy (copy-on-write) cloning via Clone().
 type T[B Boundary, P Property] struct {
-	cmp    axisds.CompareFn[B]
-	propEq PropertyEqualFn[P]
+	cmp axisds.CompareFn[B]
+	// eq is the PropertyEqualFn passed to Make (adjusted for Options.IsZero);
+	// the propEq method also takes expiry into account.
+	eq   PropertyEqualFn[P]
+	opts Options[B, P]
+	// now is the time used for Options.Deadline during the current operation,
+	// if hasNow is set; see startOp and atNow.
+	now    time.Time
+	hasNow bool
+	// lowWatermark is set by SetLowWatermark (if hasLowWatermark is set).
+	lowWatermark    B
+	hasLowWatermark bool
+	// version is incremented by every modification (see startOp); it is used by
+	// Cursor to detect modifications.
+	version uint64
+	// undo is set when there is an active checkpoint (see Checkpoint).
+	undo *undoLog[B, P]
 	// Tree maps each region start boundary to its property. The region ends at
 	// the next rgion's start boundary. The last region has zero property.
 	tree *btreemap.BTreeMap[B, P]
 }
 
+// Region is a range [Start, End) along with its property.
+type Region[B Boundary, P Property] struct {
+	Start, End B
+	Prop       P
+}
+
 // Make creates a new region tree with the given boundary and property
 // comparison functions.
 func Make[B Boundary, P Property](cmp axisds.CompareFn[B], propEq PropertyEqualFn[P]) T[B, P] {
+	return MakeWithOptions(cmp, propEq, Options[B, P]{})
+}
+
+// MakeWithOptions creates a new region tree with the given boundary and
+// property comparison functions and options.
+func MakeWithOptions[B Boundary, P Property](
+	cmp axisds.CompareFn[B], propEq PropertyEqualFn[P], opts Options[B, P],
+) T[B, P] {
+	if opts.Degree == 0 {
+		opts.Degree = defaultDegree
+	}
+	if opts.Degree < 2 {
+		panic("invalid degree")
+	}
+	if opts.MaxBoundaries != 0 && (opts.MaxBoundaries < 2 || opts.MergeFn == nil) {
+		panic("MaxBoundaries must be at least 2 and requires MergeFn")
+	}
+	if normalize := opts.NormalizeBoundary; normalize != nil {
+		baseCmp := cmp
+		cmp = func(a, b B) int {
+			return baseCmp(normalize(a), normalize(b))
+		}
+	}
+	if isZero := opts.IsZero; isZero != nil {
+		var zeroProp P
+		if !isZero(zeroProp) {
+			panic("IsZero must return true for the zero value")
+		}
+		baseEq := propEq
+		propEq = func(a, b P) bool {
+			return baseEq(a, b) || (isZero(a) && isZero(b))
+		}
+	}
+	if opLog := opts.OpLog; opLog != nil {
+		onChange := opts.OnChange
+		opts.OnChange = func(start, end B, oldProp, newProp P) {
+			if onChange != nil {
+				onChange(start, end, oldProp, newProp)
+			}
+			opLog(Region[B, P]{Start: start, End: end, Prop: newProp})
+		}
+	}
+	if opts.Deadline != nil && opts.Clock == nil {
+		opts.Clock = time.Now
+	}
 	t := T[B, P]{
-		cmp:    cmp,
-		propEq: propEq,
+		cmp:  cmp,
+		eq:   propEq,
+		opts: opts,
 	}
-	t.tree = btreemap.New[B, P](8, btreemap.CmpFunc[B](cmp))
+	t.tree = t.newBTree()
 	return t
 }
 
+func (t *T[B, P]) newBTree() *btreemap.BTreeMap[B, P] {
+	if t.opts.NodePool != nil {
+		return btreemap.NewWithFreeList[B, P](t.opts.Degree, btreemap.CmpFunc[B](t.cmp), t.opts.NodePool.freeList)
+	}
+	return btreemap.New[B, P](t.opts.Degree, btreemap.CmpFunc[B](t.cmp))
+}
+
+// newEmpty returns a new empty tree with the same settings as t (except for
+// the OnChange hook).
+func (t *T[B, P]) newEmpty() T[B, P] {
+	opts := t.opts
+	opts.OnChange = nil
+	opts.OpLog = nil
+	// IsZero is already incorporated in t.eq.
+	opts.IsZero = nil
+	// NormalizeBoundary is already incorporated in t.cmp; we restore it after
+	// creating the tree, so that it continues to apply to modifications.
+	normalize := opts.NormalizeBoundary
+	opts.NormalizeBoundary = nil
+	res := MakeWithOptions(t.cmp, t.eq, opts)
+	res.opts.NormalizeBoundary = normalize
+	return res
+}
+
+// inputBoundary applies Options.NormalizeBoundary and Options.Quantize (if
+// set) to a boundary passed to a modification.
+func (t *T[B, P]) inputBoundary(b B) B {
+	if t.opts.NormalizeBoundary != nil {
+		b = t.opts.NormalizeBoundary(b)
+	}
+	if t.opts.Quantize != nil {
+		b = t.opts.Quantize(b)
+	}
+	return b
+}
+
+// notifyChange calls the OnChange hook (if set) when the property of a range
+// changed.
+func (t *T[B, P]) notifyChange(start, end B, oldProp, newProp P) {
+	if t.opts.OnChange != nil && !t.propEq(oldProp, newProp) {
+		t.opts.OnChange(start, end, t.normalize(oldProp), newProp)
+	}
+}
+
+// notifyDelete calls the OnChange hook (if set) for all regions with non-zero
+// property in [start, end), which are about to be removed.
+func (t *T[B, P]) notifyDelete(start, end B) {
+	if t.opts.OnChange != nil {
+		var zeroProp P
+		t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+			t.opts.OnChange(rStart, rEnd, prop, zeroProp)
+			return true
+		})
+	}
+}
+
 // Update the property for the given range. The updateProp function is called
 // for all the regions within the range to calculate the new property.
 //
+// Returns true if the property of any part of the range has changed (i.e. the
+// new property is not equal to the old one).
+//
 // The runtime complexity is O(log N + K) where K is the number of regions we
 // are updating. Note that if the ranges we update are mostly non-overlapping,
 // this will be O(log N) on average.
-func (t *T[B, P]) Update(start, end B, updateProp func(p P) P) {
+func (t *T[B, P]) Update(start, end B, updateProp func(p P) P) (changed bool) {
+	return t.update(start, end, func(_, _ B, p P) P { return updateProp(p) })
+}
+
+// UpdateWithSpan is a variant of Update where the updateProp function also
+// receives the extent of the region being updated. The extent is always within
+// [start, end); a region that straddles start or end is split.
+func (t *T[B, P]) UpdateWithSpan(
+	start, end B, updateProp func(rStart, rEnd B, p P) P,
+) (changed bool) {
+	return t.update(start, end, updateProp)
+}
+
+// UpdateIf is a variant of Update which only modifies the regions (within the
+// range) with properties that satisfy the given predicate. The predicate is
+// called for all regions in the range, including those with zero property.
+//
+// Returns whether the predicate was satisfied by any region and whether it was
+// satisfied by all regions in the range.
+func (t *T[B, P]) UpdateIf(
+	start, end B, pred func(p P) bool, updateProp func(p P) P,
+) (anyMatched, allMatched bool) {
+	if t.cmp(start, end) >= 0 {
+		return false, true
+	}
+	allMatched = true
+	t.update(start, end, func(_, _ B, p P) P {
+		if !pred(p) {
+			allMatched = false
+			return p
+		}
+		anyMatched = true
+		return updateProp(p)
+	})
+	return anyMatched, allMatched
+}
+
+func (t *T[B, P]) update(start, end B, updateProp func(rStart, rEnd B, p P) P) (changed bool) {
+	defer t.endOp(t.startOp())
+	start, end = t.aboveWatermark(t.inputBoundary(start)), t.aboveWatermark(t.inputBoundary(end))
+	if t.cmp(start, end) >= 0 {
+		return false
+	}
+	t.recordRange(start, end)
+	if t.opts.MaxBoundaries > 0 {
+		// Note: deferred before validation, so it runs after it.
+		defer t.maybeCoarsen()
+	}
+	if snapshot := t.validationSnapshot(); snapshot != nil {
+		defer func() {
+			t.validateMutation(snapshot, start, end)
+			if !changed {
+				t.validateUnchanged(snapshot, start, end)
+			}
+		}()
+	}
 	// Get information about the region before start.
 	startBoundaryExists, beforeProp := t.startBoundaryInfo(start)
 	endBoundaryExists, afterProp := t.endBoundaryInfo(end)
@@ -79,15 +263,6 @@ func (t *T[B, P]) Update(start, end B, updateProp func(p P) P) {
 	lastProp := beforeProp
 	var startProp P
 	var addStartBoundary bool
-	if !startBoundaryExists {
-		// See if we need to add the start boundary.
-		startProp = updateProp(beforeProp)
-		if !t.propEq(startProp, lastProp) {
-			// We will add the start boundary with startProp.
-			addStartBoundary = true
-		}
-		lastProp = startProp
-	}
 
 	type update struct {
 		start  B
@@ -95,18 +270,42 @@ func (t *T[B, P]) Update(start, end B, updateProp func(p P) P) {
 		delete bool
 	}
 	var updates []update
-	// Collect all the boundaries in the range that need to be updated or deleted.
-	t.tree.AscendFunc(btreemap.GE(start), btreemap.LT(end), func(rStart B, rProp P) bool {
-		prop := updateProp(rProp)
-		if t.propEq(prop, lastProp) {
+
+	// We can only call updateProp for a region once we know where the region
+	// ends, so we keep track of the "pending" region [pStart, <next boundary>).
+	pStart, pProp, pIsBoundary := start, beforeProp, false
+	processPending := func(rEnd B) {
+		prop := updateProp(pStart, rEnd, t.normalize(pProp))
+		propChanged := !t.propEq(prop, pProp)
+		if propChanged {
+			t.notifyChange(pStart, rEnd, pProp, prop)
+		}
+		switch {
+		case !pIsBoundary:
+			if propChanged {
+				// We will add the start boundary with startProp.
+				addStartBoundary = true
+				startProp = prop
+			}
+		case t.propEq(prop, lastProp):
 			// Boundary not necessary; remove it.
-			updates = append(updates, update{start: rStart, delete: true})
-		} else if !t.propEq(prop, rProp) {
-			updates = append(updates, update{start: rStart, prop: prop, delete: false})
+			updates = append(updates, update{start: pStart, delete: true})
+		case propChanged:
+			updates = append(updates, update{start: pStart, prop: prop, delete: false})
 		}
+		changed = changed || propChanged
 		lastProp = prop
+	}
+
+	// Collect all the boundaries in the range that need to be updated or deleted.
+	t.tree.AscendFunc(btreemap.GE(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		if pIsBoundary || !startBoundaryExists {
+			processPending(rStart)
+		}
+		pStart, pProp, pIsBoundary = rStart, rProp, true
 		return true
 	})
+	processPending(end)
 
 	if addStartBoundary {
 		t.tree.ReplaceOrInsert(start, startProp)
@@ -131,6 +330,179 @@ func (t *T[B, P]) Update(start, end B, updateProp func(p P) P) {
 			t.tree.ReplaceOrInsert(end, afterProp)
 		}
 	}
+	return changed
+}
+
+// Set sets the property for the given range to a constant value. It is
+// equivalent to an Update with a function that returns prop, except that all
+// boundaries inside the range are removed directly.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) Set(start, end B, prop P) {
+	defer t.endOp(t.startOp())
+	t.set(t.aboveWatermark(t.inputBoundary(start)), t.aboveWatermark(t.inputBoundary(end)), prop)
+}
+
+// set implements Set, without applying Options.NormalizeBoundary and
+// Options.Quantize to the boundaries. It is used to restore or replay regions
+// with boundaries that come from a tree (see Rollback and Apply).
+func (t *T[B, P]) set(start, end B, prop P) {
+	if t.cmp(start, end) >= 0 {
+		return
+	}
+	t.recordRange(start, end)
+	if t.opts.MaxBoundaries > 0 {
+		// Note: deferred before validation, so it runs after it.
+		defer t.maybeCoarsen()
+	}
+	if snapshot := t.validationSnapshot(); snapshot != nil {
+		defer func() {
+			t.validateMutation(snapshot, start, end)
+			t.validateRegions(start, end, []boundaryProp[B, P]{{b: start, prop: prop}})
+		}()
+	}
+	_, beforeProp := t.startBoundaryInfo(start)
+	endBoundaryExists, afterProp := t.endBoundaryInfo(end)
+
+	var toDelete []B
+	// [cur, ...) is the part of the range we haven't processed yet, with
+	// curProp the existing property.
+	cur, curProp := start, beforeProp
+	t.tree.AscendFunc(btreemap.GE(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		toDelete = append(toDelete, rStart)
+		if t.cmp(cur, rStart) < 0 {
+			t.notifyChange(cur, rStart, curProp, prop)
+		}
+		cur, curProp = rStart, rProp
+		return true
+	})
+	t.notifyChange(cur, end, curProp, prop)
+	for _, b := range toDelete {
+		t.tree.Delete(b)
+	}
+
+	if !t.propEq(beforeProp, prop) {
+		t.tree.ReplaceOrInsert(start, prop)
+	}
+	if t.propEq(afterProp, prop) {
+		if endBoundaryExists {
+			// End boundary is no longer necessary.
+			t.tree.Delete(end)
+		}
+	} else if !endBoundaryExists {
+		t.tree.ReplaceOrInsert(end, afterProp)
+	}
+	t.removeRedundantBefore(start)
+}
+
+// removeRedundantBefore removes the last boundary below b if it is no longer
+// necessary, i.e. the property of its region has become equal to the property
+// of the previous region (which can happen with an evolving PropertyEqualFn or
+// with expiry). This prevents delete-heavy workloads from accumulating
+// boundaries in front of the modified ranges.
+func (t *T[B, P]) removeRedundantBefore(b B) {
+	var boundaries [2]B
+	var props [2]P
+	n := 0
+	t.tree.DescendFunc(btreemap.LT(b), btreemap.Min[B](), func(rStart B, rProp P) bool {
+		boundaries[n], props[n] = rStart, rProp
+		n++
+		return n < len(boundaries)
+	})
+	// If there is no previous boundary, the previous region has zero property.
+	if n > 0 && t.propEq(props[0], props[1]) {
+		t.tree.Delete(boundaries[0])
+	}
+}
+
+// Delete sets the property to zero for the given range. It is equivalent to
+// Set with the zero property.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) Delete(start, end B) {
+	defer t.endOp(t.startOp())
+	var zeroProp P
+	t.Set(start, end, zeroProp)
+}
+
+// boundaryProp is a region start boundary along with the region's property.
+type boundaryProp[B Boundary, P Property] struct {
+	b    B
+	prop P
+}
+
+// setRegions replaces the regions in [start, end) with the given regions.
+// The regions must be in increasing order, the first one must start at start
+// and the last one is assumed to end at end.
+//
+// Only the boundaries that need to change are modified.
+func (t *T[B, P]) setRegions(start, end B, regions []boundaryProp[B, P]) {
+	t.recordRange(start, end)
+	if t.opts.MaxBoundaries > 0 {
+		// Note: deferred before validation, so it runs after it.
+		defer t.maybeCoarsen()
+	}
+	if snapshot := t.validationSnapshot(); snapshot != nil {
+		defer func() {
+			t.validateMutation(snapshot, start, end)
+			t.validateRegions(start, end, regions)
+		}()
+	}
+	_, beforeProp := t.startBoundaryInfo(start)
+	endBoundaryExists, afterProp := t.endBoundaryInfo(end)
+
+	// Calculate the boundaries we need inside the range.
+	var desired []boundaryProp[B, P]
+	lastProp := beforeProp
+	for _, r := range regions {
+		if !t.propEq(r.prop, lastProp) {
+			desired = append(desired, r)
+			lastProp = r.prop
+		}
+	}
+	var existing []boundaryProp[B, P]
+	t.tree.AscendFunc(btreemap.GE(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		existing = append(existing, boundaryProp[B, P]{b: rStart, prop: rProp})
+		return true
+	})
+
+	// Merge the two sorted lists.
+	i, j := 0, 0
+	for i < len(existing) || j < len(desired) {
+		var c int
+		switch {
+		case i == len(existing):
+			c = +1
+		case j == len(desired):
+			c = -1
+		default:
+			c = t.cmp(existing[i].b, desired[j].b)
+		}
+		switch {
+		case c < 0:
+			t.tree.Delete(existing[i].b)
+			i++
+		case c > 0:
+			t.tree.ReplaceOrInsert(desired[j].b, desired[j].prop)
+			j++
+		default:
+			if !t.propEq(existing[i].prop, desired[j].prop) {
+				t.tree.ReplaceOrInsert(desired[j].b, desired[j].prop)
+			}
+			i++
+			j++
+		}
+	}
+
+	if t.propEq(lastProp, afterProp) {
+		if endBoundaryExists {
+			t.tree.Delete(end)
+		}
+	} else if !endBoundaryExists {
+		t.tree.ReplaceOrInsert(end, afterProp)
+	}
 }
 
 // startBoundaryInfo checks if the boundary exists and returns the property
@@ -198,12 +570,85 @@ func (t *T[B, P]) endBoundaryInfo(end B) (exists bool, afterProp P) {
 //
 // Enumerate stops once emit() returns false.
 //
-// Enumerate can be called concurrently with other read-only methods (Enumerate,
-// EnumerateAll, Any).
+// Enumerate never modifies the tree: unnecessary boundaries (between regions
+// with properties that have become equal) are left in place, to be removed
+// later by Compact (or by the *WithGC variants). Enumerate can be called
+// concurrently with other read-only methods (Enumerate, EnumerateAll, Any,
+// IsEmpty).
 func (t *T[B, P]) Enumerate(start, end B, emit func(start, end B, prop P) bool) {
+	t = t.atNow()
 	t.enumerate(start, end, emit, false /* with GC */)
 }
 
+// EnumerateN emits the first (up to) n regions in the range [start, end) with
+// non-zero property, like Enumerate. It returns true if the result was
+// truncated, i.e. there are more than n such regions in the range. Panics if n
+// is negative.
+//
+// The runtime complexity is O(log N + n).
+func (t *T[B, P]) EnumerateN(start, end B, n int, emit func(start, end B, prop P)) (truncated bool) {
+	t = t.atNow()
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	count := 0
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		if count == n {
+			truncated = true
+			return false
+		}
+		count++
+		emit(rStart, rEnd, prop)
+		return true
+	})
+	return truncated
+}
+
+// EnumeratePage emits up to limit regions in the range [start, end) with
+// non-zero property, like EnumerateN, and returns a resume boundary if there
+// are more regions in the range. The next page is obtained by calling
+// EnumeratePage(next, end, ...).
+//
+// The resume boundary can be passed around like an opaque token (e.g. in a
+// URL) and remains valid if the tree is modified between calls: the next page
+// contains the regions at or after the resume boundary, as of the time of the
+// next call.
+//
+// The runtime complexity is O(log N + limit).
+func (t *T[B, P]) EnumeratePage(
+	start, end B, limit int, emit func(start, end B, prop P),
+) (next B, more bool) {
+	t = t.atNow()
+	if limit <= 0 {
+		panic("limit must be positive")
+	}
+	more = t.EnumerateN(start, end, limit, func(rStart, rEnd B, prop P) {
+		next = rEnd
+		emit(rStart, rEnd, prop)
+	})
+	if !more {
+		var zero B
+		next = zero
+	}
+	return next, more
+}
+
+// AppendRegions appends all regions in the range [start, end) with non-zero
+// property to dst (in the same way as Enumerate) and returns the resulting
+// slice. When dst has enough capacity, no allocations are performed, so a
+// buffer can be reused across calls.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) AppendRegions(dst []Region[B, P], start, end B) []Region[B, P] {
+	t = t.atNow()
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		dst = append(dst, Region[B, P]{Start: rStart, End: rEnd, Prop: prop})
+		return true
+	})
+	return dst
+}
+
 // EnumerateWithGC is a variant of Enumerate which internally deletes
 // unnecessary boundaries between regions with properties that have become
 // equal.
@@ -212,6 +657,7 @@ func (t *T[B, P]) Enumerate(start, end B, emit func(start, end B, prop P) bool)
 // can change over time. It cannot be called concurrently with any other
 // methods.
 func (t *T[B, P]) EnumerateWithGC(start, end B, emit func(start, end B, prop P) bool) {
+	defer t.endOp(t.startOp())
 	t.enumerate(start, end, emit, true /* with GC */)
 }
 
@@ -243,12 +689,98 @@ func (t *T[B, P]) enumerate(start, end B, emit func(start, end B, prop P) bool,
 	}
 }
 
+// EnumerateDescending emits all regions in the range [start, end) with
+// non-zero property, in descending order.
+//
+// Two consecutive regions can "touch" but not overlap; if they touch, their
+// properties are not equal.
+//
+// EnumerateDescending stops once emit() returns false.
+//
+// EnumerateDescending can be called concurrently with other read-only methods
+// (Enumerate, EnumerateAll, Any).
+func (t *T[B, P]) EnumerateDescending(start, end B, emit func(start, end B, prop P) bool) {
+	t = t.atNow()
+	if t.tree.Len() < 2 || t.cmp(start, end) >= 0 {
+		return
+	}
+	var zeroProp P
+	// The region [curStart, curEnd) with curProp is pending; it could still be
+	// extended to the left if the preceding region has an equal property.
+	curEnd := end
+	var curStart B
+	var curProp P
+	initialized := false
+	stopped := false
+	t.tree.DescendFunc(btreemap.LT(end), btreemap.Min[B](), func(rStart B, rProp P) bool {
+		atStart := t.cmp(rStart, start) <= 0
+		if atStart {
+			rStart = start
+		}
+		switch {
+		case !initialized:
+			curStart, curProp = rStart, rProp
+			initialized = true
+		case t.propEq(curProp, rProp):
+			curStart = rStart
+		default:
+			if !t.propEq(curProp, zeroProp) && !emit(curStart, curEnd, curProp) {
+				stopped = true
+				return false
+			}
+			curEnd = curStart
+			curStart, curProp = rStart, rProp
+		}
+		return !atStart
+	})
+	if initialized && !stopped && !t.propEq(curProp, zeroProp) {
+		emit(curStart, curEnd, curProp)
+	}
+}
+
+// FindFirst returns the first region (in increasing order) within
+// [start, end) with non-zero property that satisfies the given predicate. The
+// region is truncated to the range. Returns ok=false if there is no such
+// region.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions
+// before the resulting region.
+func (t *T[B, P]) FindFirst(start, end B, pred func(prop P) bool) (rStart, rEnd B, prop P, ok bool) {
+	t = t.atNow()
+	t.Enumerate(start, end, func(s, e B, p P) bool {
+		if pred(p) {
+			rStart, rEnd, prop, ok = s, e, p, true
+		}
+		return !ok
+	})
+	return rStart, rEnd, prop, ok
+}
+
+// CountWhere returns the number of regions within [start, end) with non-zero
+// property that satisfies the given predicate. Regions are counted as emitted
+// by Enumerate (neighboring regions with equal properties are merged).
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) CountWhere(start, end B, pred func(prop P) bool) int {
+	t = t.atNow()
+	count := 0
+	t.Enumerate(start, end, func(_, _ B, prop P) bool {
+		if pred(prop) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
 // Any returns true if [start, end) overlaps any region with property that
 // satisfies the given function.
 //
 // Any can be called concurrently with other read-only methods (Enumerate,
-// EnumerateAll, Any).
+// EnumerateAll, Any, IsEmpty).
 func (t *T[B, P]) Any(start, end B, propFn func(prop P) bool) bool {
+	t = t.atNow()
 	return t.any(start, end, propFn, false /* withGC */)
 }
 
@@ -259,6 +791,7 @@ func (t *T[B, P]) Any(start, end B, propFn func(prop P) bool) bool {
 // can change over time. It cannot be called concurrently with any other
 // methods.
 func (t *T[B, P]) AnyWithGC(start, end B, propFn func(prop P) bool) bool {
+	defer t.endOp(t.startOp())
 	return t.any(start, end, propFn, true /* withGC */)
 }
 
@@ -291,6 +824,336 @@ func (t *T[B, P]) any(start, end B, propFn func(prop P) bool, withGC bool) bool
 	return found
 }
 
+// SeekNonZero returns the first region with non-zero property at or after b.
+// If b is inside such a region, the returned region starts at b. In other
+// words, it returns the first region that would be emitted by an Enumerate
+// call starting at b. If there is no such region, ok is false.
+//
+// The runtime complexity is O(log N + K) where K is the number of zero-property
+// regions that are skipped.
+func (t *T[B, P]) SeekNonZero(b B) (start, end B, prop P, ok bool) {
+	t = t.atNow()
+	var zeroProp P
+	cur := b
+	_, curProp := t.endBoundaryInfo(b)
+	t.tree.AscendFunc(btreemap.GT(b), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		if !ok {
+			if t.propEq(curProp, zeroProp) {
+				cur, curProp = rStart, rProp
+				return true
+			}
+			start, prop, ok = cur, curProp, true
+		}
+		if t.propEq(rProp, prop) {
+			// Unnecessary boundary.
+			return true
+		}
+		end = rStart
+		return false
+	})
+	return start, end, prop, ok
+}
+
+// PrevNonZero returns the last region with non-zero property before b. If b
+// is inside such a region, the returned region ends at b. In other words, it
+// returns the first region that would be emitted by an EnumerateDescending call
+// ending at b. If there is no such region, ok is false.
+//
+// The runtime complexity is O(log N + K) where K is the number of zero-property
+// regions that are skipped.
+func (t *T[B, P]) PrevNonZero(b B) (start, end B, prop P, ok bool) {
+	t = t.atNow()
+	var zeroProp P
+	end = b
+	t.tree.DescendFunc(btreemap.LT(b), btreemap.Min[B](), func(rStart B, rProp P) bool {
+		if !ok {
+			if t.propEq(rProp, zeroProp) {
+				end = rStart
+				return true
+			}
+			start, prop, ok = rStart, rProp, true
+			return true
+		}
+		if !t.propEq(rProp, prop) {
+			return false
+		}
+		// Unnecessary boundary.
+		start = rStart
+		return true
+	})
+	if !ok {
+		var zeroB B
+		return zeroB, zeroB, prop, false
+	}
+	return start, end, prop, true
+}
+
+// Neighbors returns the region with non-zero property which contains b (if
+// any), along with the closest regions with non-zero property before and after
+// it (or before and after b, if b is not inside a region). Regions are not
+// truncated; regions that are absent are nil. The previous (or next) region
+// touches the current region if its end (or start) is equal to the current
+// region's start (or end).
+//
+// The runtime complexity is O(log N) (plus the number of zero-property regions
+// that are skipped).
+func (t *T[B, P]) Neighbors(b B) (prev, cur, next *Region[B, P]) {
+	t = t.atNow()
+	makeRegion := func(start, end B, prop P, ok bool) *Region[B, P] {
+		if !ok {
+			return nil
+		}
+		return &Region[B, P]{Start: start, End: end, Prop: prop}
+	}
+	prev = makeRegion(t.PrevNonZero(b))
+	next = makeRegion(t.SeekNonZero(b))
+	if next == nil || t.cmp(next.Start, b) != 0 {
+		// b is not inside a region (or at the start of one).
+		return prev, nil, next
+	}
+	cur = next
+	if prev != nil && t.cmp(prev.End, b) == 0 && t.propEq(prev.Prop, cur.Prop) {
+		// b is inside a region; prev is the part of the region before b.
+		cur.Start = prev.Start
+		prev = makeRegion(t.PrevNonZero(cur.Start))
+	}
+	return prev, cur, makeRegion(t.SeekNonZero(cur.End))
+}
+
+// EnumerateGaps emits the maximal sub-ranges of [start, end) that are not
+// covered by any region with non-zero property, in increasing order.
+//
+// EnumerateGaps stops once emit() returns false.
+//
+// EnumerateGaps can be called concurrently with other read-only methods
+// (Enumerate, EnumerateAll, Any).
+func (t *T[B, P]) EnumerateGaps(start, end B, emit func(start, end B) bool) {
+	t = t.atNow()
+	if t.cmp(start, end) >= 0 {
+		return
+	}
+	gapStart := start
+	stopped := false
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		if t.cmp(gapStart, rStart) < 0 && !emit(gapStart, rStart) {
+			stopped = true
+			return false
+		}
+		gapStart = rEnd
+		return true
+	})
+	if !stopped && t.cmp(gapStart, end) < 0 {
+		emit(gapStart, end)
+	}
+}
+
+// EnumerateWithGaps emits all regions in [start, end), including the gaps
+// between regions with non-zero property (which are emitted with the zero
+// property). The emitted regions form a complete partition of [start, end).
+//
+// Neighboring regions are merged when their properties are equal (in
+// particular, neighboring gaps are emitted as a single region).
+//
+// EnumerateWithGaps stops once emit() returns false.
+//
+// EnumerateWithGaps can be called concurrently with other read-only methods
+// (Enumerate, EnumerateAll, Any).
+func (t *T[B, P]) EnumerateWithGaps(start, end B, emit func(start, end B, prop P) bool) {
+	t = t.atNow()
+	if t.cmp(start, end) >= 0 {
+		return
+	}
+	var zeroProp P
+	gapStart := start
+	stopped := false
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		if t.cmp(gapStart, rStart) < 0 && !emit(gapStart, rStart, zeroProp) {
+			stopped = true
+			return false
+		}
+		gapStart = rEnd
+		if !emit(rStart, rEnd, prop) {
+			stopped = true
+			return false
+		}
+		return true
+	})
+	if !stopped && t.cmp(gapStart, end) < 0 {
+		emit(gapStart, end, zeroProp)
+	}
+}
+
+// Overlaps returns true if any point in [start, end) is inside a region with
+// non-zero property. It stops at the first such region.
+//
+// Overlaps can be called concurrently with other read-only methods (Enumerate,
+// EnumerateAll, Any).
+func (t *T[B, P]) Overlaps(start, end B) bool {
+	t = t.atNow()
+	if t.cmp(start, end) >= 0 {
+		return false
+	}
+	var zeroProp P
+	if _, prop := t.endBoundaryInfo(start); !t.propEq(prop, zeroProp) {
+		return true
+	}
+	overlaps := false
+	t.tree.AscendFunc(btreemap.GT(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		overlaps = !t.propEq(rProp, zeroProp)
+		return !overlaps
+	})
+	return overlaps
+}
+
+// ContainsRange returns true if every point in [start, end) is inside a region
+// with non-zero property. It stops at the first gap.
+//
+// ContainsRange can be called concurrently with other read-only methods
+// (Enumerate, EnumerateAll, Any).
+func (t *T[B, P]) ContainsRange(start, end B) bool {
+	t = t.atNow()
+	if t.cmp(start, end) >= 0 {
+		return true
+	}
+	var zeroProp P
+	if _, prop := t.endBoundaryInfo(start); t.propEq(prop, zeroProp) {
+		return false
+	}
+	contains := true
+	t.tree.AscendFunc(btreemap.GT(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		contains = !t.propEq(rProp, zeroProp)
+		return contains
+	})
+	return contains
+}
+
+// RangeProperty returns the property of [start, end) if the entire range has
+// the same property (possibly zero), or ok=false otherwise. It stops at the
+// first property change. For an empty range, it returns the zero property.
+//
+// The runtime complexity is O(log N) when the range is uniform (assuming no
+// unnecessary boundaries).
+//
+// RangeProperty can be called concurrently with other read-only methods
+// (Enumerate, EnumerateAll, Any).
+func (t *T[B, P]) RangeProperty(start, end B) (prop P, ok bool) {
+	t = t.atNow()
+	if t.cmp(start, end) >= 0 {
+		return prop, true
+	}
+	_, prop = t.endBoundaryInfo(start)
+	ok = true
+	t.tree.AscendFunc(btreemap.GT(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		ok = t.propEq(rProp, prop)
+		return ok
+	})
+	if !ok {
+		var zeroProp P
+		return zeroProp, false
+	}
+	return t.normalize(prop), true
+}
+
+// IsUniform returns true if every point in [start, end) has a property that
+// satisfies the given predicate (including points outside of any region, which
+// have the zero property). It stops at the first violation.
+//
+// IsUniform can be called concurrently with other read-only methods
+// (Enumerate, EnumerateAll, Any).
+func (t *T[B, P]) IsUniform(start, end B, pred func(prop P) bool) bool {
+	t = t.atNow()
+	if t.cmp(start, end) >= 0 {
+		return true
+	}
+	if _, prop := t.endBoundaryInfo(start); !pred(t.normalize(prop)) {
+		return false
+	}
+	uniform := true
+	t.tree.AscendFunc(btreemap.GT(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		uniform = pred(t.normalize(rProp))
+		return uniform
+	})
+	return uniform
+}
+
+// Boundaries returns an iterator over the boundaries in [start, end) where the
+// property changes (i.e. the starts and ends of regions with non-zero
+// property), in increasing order. Unnecessary boundaries that are stored
+// internally are skipped.
+//
+// The iterator can be used concurrently with other read-only methods
+// (Enumerate, EnumerateAll, Any). The tree must not be modified while it is in
+// use.
+func (t *T[B, P]) Boundaries(start, end B) iter.Seq[B] {
+	return func(yield func(B) bool) {
+		t := t.atNow()
+		if t.cmp(start, end) >= 0 {
+			return
+		}
+		_, lastProp := t.startBoundaryInfo(start)
+		t.tree.AscendFunc(btreemap.GE(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+			if t.propEq(rProp, lastProp) {
+				return true
+			}
+			lastProp = rProp
+			return yield(rStart)
+		})
+	}
+}
+
+// FloorBoundary returns the largest boundary at or below b where the property
+// changes (i.e. the start or end of a region with non-zero property).
+// Unnecessary boundaries that are stored internally are skipped. Returns
+// ok=false if there is no such boundary.
+//
+// The runtime complexity is O(log N) (plus the number of unnecessary
+// boundaries that are skipped).
+func (t *T[B, P]) FloorBoundary(b B) (_ B, ok bool) {
+	t = t.atNow()
+	var res B
+	var resProp P
+	found, changed := false, false
+	t.tree.DescendFunc(btreemap.LE(b), btreemap.Min[B](), func(rStart B, rProp P) bool {
+		if found && !t.propEq(rProp, resProp) {
+			// The property changes at res.
+			changed = true
+			return false
+		}
+		res, resProp, found = rStart, rProp, true
+		return true
+	})
+	if !found {
+		return res, false
+	}
+	if !changed {
+		// We reached the first boundary; the property before it is zero.
+		var zeroProp P
+		return res, !t.propEq(resProp, zeroProp)
+	}
+	return res, true
+}
+
+// CeilingBoundary returns the smallest boundary at or above b where the
+// property changes (i.e. the start or end of a region with non-zero property).
+// Unnecessary boundaries that are stored internally are skipped. Returns
+// ok=false if there is no such boundary.
+//
+// The runtime complexity is O(log N) (plus the number of unnecessary
+// boundaries that are skipped).
+func (t *T[B, P]) CeilingBoundary(b B) (_ B, ok bool) {
+	t = t.atNow()
+	var res B
+	_, lastProp := t.startBoundaryInfo(b)
+	t.tree.AscendFunc(btreemap.GE(b), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		if t.propEq(rProp, lastProp) {
+			return true
+		}
+		res, ok = rStart, true
+		return false
+	})
+	return res, ok
+}
+
 // EnumerateAll emits all regions with non-zero property.
 //
 // Two consecutive regions can "touch" but not overlap; if they touch, their
@@ -298,9 +1161,10 @@ func (t *T[B, P]) any(start, end B, propFn func(prop P) bool, withGC bool) bool
 //
 // EnumerateAll stops once emit() returns false.
 //
-// Enumerate can be called concurrently with other read-only methods (Enumerate,
-// EnumerateAll, Any).
+// EnumerateAll never modifies the tree and can be called concurrently with
+// other read-only methods (Enumerate, EnumerateAll, Any, IsEmpty).
 func (t *T[B, P]) EnumerateAll(emit func(start, end B, prop P) bool) {
+	t = t.atNow()
 	t.enumerateAll(emit, false /* withGC */)
 }
 
@@ -312,6 +1176,7 @@ func (t *T[B, P]) EnumerateAll(emit func(start, end B, prop P) bool) {
 // can change over time. It cannot be called concurrently with any other
 // methods.
 func (t *T[B, P]) EnumerateAllWithGC(emit func(start, end B, prop P) bool) {
+	defer t.endOp(t.startOp())
 	t.enumerateAll(emit, true /* withGC */)
 }
 
@@ -320,7 +1185,7 @@ func (t *T[B, P]) enumerateAll(emit func(start, end B, prop P) bool, withGC bool
 	var toDelete []B
 	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
 		eh.addRegion(rStart, rProp, t.propEq, emit)
-		if eh.canDeleteLastBoundary {
+		if withGC && eh.canDeleteLastBoundary {
 			toDelete = append(toDelete, rStart)
 		}
 		return !eh.stopEmitting
@@ -370,8 +1235,55 @@ func (eh *enumerateHelper[B, P]) finish(
 	}
 }
 
+// Compact walks through the entire tree and removes all unnecessary
+// boundaries: boundaries between regions with properties that have become
+// equal, and boundaries around regions with properties that have become zero.
+//
+// Compact is only useful when the PropertyEqualFn can change over time; the
+// *WithGC methods perform the same cleanup, but only for the regions they
+// touch. The runtime complexity is O(N + K log N), where K is the number of
+// boundaries removed.
+func (t *T[B, P]) Compact() {
+	defer t.endOp(t.startOp())
+	var toDelete []B
+	// lastProp is the property of the last boundary that we are keeping.
+	var lastProp P
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		if t.propEq(rProp, lastProp) {
+			toDelete = append(toDelete, rStart)
+		} else {
+			lastProp = rProp
+		}
+		return true
+	})
+	for _, b := range toDelete {
+		t.tree.Delete(b)
+	}
+}
+
 // IsEmpty returns true if the set contains no non-expired spans.
+//
+// IsEmpty never modifies the tree and can be called concurrently with other
+// read-only methods (Enumerate, EnumerateAll, Any, IsEmpty).
 func (t *T[B, P]) IsEmpty() bool {
+	t = t.atNow()
+	empty := true
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		var zeroProp P
+		empty = t.propEq(rProp, zeroProp)
+		return empty
+	})
+	return empty
+}
+
+// IsEmptyWithGC is a variant of IsEmpty which internally deletes leading
+// boundaries with properties that have become equal to the zero property.
+//
+// This variant is only useful to improve performance when the PropertyEqualFn
+// can change over time. It cannot be called concurrently with any other
+// methods.
+func (t *T[B, P]) IsEmptyWithGC() bool {
+	defer t.endOp(t.startOp())
 	if t.tree.Len() < 2 {
 		return true
 	}
@@ -391,31 +1303,213 @@ func (t *T[B, P]) IsEmpty() bool {
 	return t.tree.Len() < 2
 }
 
+// Len returns the number of regions with non-zero property (as they would be
+// emitted by EnumerateAll).
+//
+// Len walks the entire tree, so the runtime complexity is O(N); see InternalLen
+// for a constant-time alternative that counts internal boundaries.
+func (t *T[B, P]) Len() int {
+	t = t.atNow()
+	n := 0
+	var eh enumerateHelper[B, P]
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		eh.addRegion(rStart, rProp, t.propEq, func(start, end B, prop P) bool {
+			n++
+			return true
+		})
+		return true
+	})
+	return n
+}
+
+// Bounds returns the start boundary of the first region with non-zero
+// property and the end boundary of the last region with non-zero property. If
+// there are no such regions, ok is false.
+//
+// The runtime complexity is O(log N), unless there are unnecessary boundaries
+// at the ends of the tree (which can happen when PropertyEqualFn evolves).
+func (t *T[B, P]) Bounds() (min, max B, ok bool) {
+	t = t.atNow()
+	var zeroProp P
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		if t.propEq(rProp, zeroProp) {
+			return true
+		}
+		min, ok = rStart, true
+		return false
+	})
+	if !ok {
+		return min, max, false
+	}
+	t.tree.DescendFunc(btreemap.Max[B](), btreemap.Min[B](), func(rStart B, rProp P) bool {
+		if !t.propEq(rProp, zeroProp) {
+			return false
+		}
+		// The region that ends at this boundary could be the last non-zero
+		// region.
+		max = rStart
+		return true
+	})
+	return min, max, true
+}
+
+// FirstN returns the first (up to) n regions with non-zero property, in
+// increasing order.
+//
+// The runtime complexity is O(log N + n).
+func (t *T[B, P]) FirstN(n int) []Region[B, P] {
+	t = t.atNow()
+	if n <= 0 {
+		return nil
+	}
+	var res []Region[B, P]
+	t.EnumerateAll(func(start, end B, prop P) bool {
+		res = append(res, Region[B, P]{Start: start, End: end, Prop: prop})
+		return len(res) < n
+	})
+	return res
+}
+
+// LastN returns the last (up to) n regions with non-zero property, in
+// increasing order.
+//
+// The runtime complexity is O(log N + n).
+func (t *T[B, P]) LastN(n int) []Region[B, P] {
+	t = t.atNow()
+	start, end, ok := t.Bounds()
+	if !ok || n <= 0 {
+		return nil
+	}
+	var res []Region[B, P]
+	t.EnumerateDescending(start, end, func(start, end B, prop P) bool {
+		res = append(res, Region[B, P]{Start: start, End: end, Prop: prop})
+		return len(res) < n
+	})
+	slices.Reverse(res)
+	return res
+}
+
 // InternalLen returns the number of region boundaries stored internally.
 func (t *T[B, P]) InternalLen() int {
 	return t.tree.Len()
 }
 
+// Reset removes all regions, returning the tree to the state after Make. The
+// internal nodes are recycled (when they are not shared with a clone), so a
+// tree can be reused without reallocating. If the tree uses a NodePool, the
+// nodes are returned to the pool.
+func (t *T[B, P]) Reset() {
+	defer t.endOp(t.startOp())
+	t.recordSnapshot()
+	if t.opts.OnChange != nil {
+		if start, end, ok := t.Bounds(); ok {
+			t.notifyDelete(start, end)
+		}
+	}
+	t.tree.Clear(true /* addNodesToFreelist */)
+}
+
+// Shift translates all regions by applying shiftFn to every boundary. The
+// function must be strictly increasing (e.g. adding a fixed delta); Shift
+// panics if the order of the boundaries is not preserved.
+//
+// The OnChange hook (if set) is called for the removal of all the regions,
+// followed by the addition of all the shifted regions.
+//
+// The runtime complexity is O(N log N).
+func (t *T[B, P]) Shift(shiftFn func(b B) B) {
+	defer t.endOp(t.startOp())
+	t.recordSnapshot()
+	if start, end, ok := t.Bounds(); ok && t.opts.OnChange != nil {
+		t.notifyDelete(start, end)
+	}
+	newTree := t.newBTree()
+	var last B
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		b := shiftFn(rStart)
+		if newTree.Len() > 0 && t.cmp(last, b) >= 0 {
+			panic("shift function does not preserve boundary order")
+		}
+		newTree.ReplaceOrInsert(b, rProp)
+		last = b
+		return true
+	})
+	t.tree = newTree
+	if t.hasLowWatermark {
+		t.lowWatermark = shiftFn(t.lowWatermark)
+	}
+	if t.opts.OnChange != nil {
+		var zeroProp P
+		t.EnumerateAll(func(rStart, rEnd B, prop P) bool {
+			t.opts.OnChange(rStart, rEnd, zeroProp, prop)
+			return true
+		})
+	}
+}
+
 // Clone creates a lazy clone of T with the same properties and regions. The new
 // tree can be modified independently.
 //
 // This operation is constant time; it can cause some minor slowdown of future
-// updates because of copy-on-write logic.
+// updates because of copy-on-write logic. If Options.CloneProp is set, the
+// properties are copied and the runtime complexity is O(N log N).
 func (t *T[B, P]) Clone() T[B, P] {
-	return T[B, P]{
-		cmp:    t.cmp,
-		propEq: t.propEq,
-		tree:   t.tree.Clone(),
+	c := *t
+	c.opts.OnChange = nil
+	c.opts.OpLog = nil
+	c.undo = nil
+	// The clone reads the clock for its own operations (see startOp).
+	c.endOp(c.hasNow)
+	if cloneProp := t.opts.CloneProp; cloneProp != nil {
+		c.tree = t.newBTree()
+		t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+			c.tree.ReplaceOrInsert(rStart, cloneProp(rProp))
+			return true
+		})
+		return c
 	}
+	c.tree = t.tree.Clone()
+	return c
+}
+
+// Materialize makes a full copy of the internal structure of the tree, so that
+// it no longer shares any nodes with clones (or with the tree it was cloned
+// from). Subsequent modifications don't incur any copy-on-write costs, which
+// can be useful for long-lived clones where latency matters.
+//
+// Note that the internal B-tree does not expose which of its nodes are shared,
+// so there is no way to query how much of a tree is still shared with its
+// clones.
+//
+// The runtime complexity is O(N log N).
+func (t *T[B, P]) Materialize() {
+	newTree := t.newBTree()
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		newTree.ReplaceOrInsert(rStart, rProp)
+		return true
+	})
+	t.tree = newTree
 }
 
-// String formats all regions, one per line.
+// String formats all regions, one per line. Properties are formatted with %v.
 func (t *T[B, P]) String(iFmt axisds.IntervalFormatter[B]) string {
+	t = t.atNow()
+	return t.StringWithPropFormatter(iFmt, func(prop P) string {
+		return fmt.Sprint(prop)
+	})
+}
+
+// StringWithPropFormatter formats all regions, one per line, using the given
+// function to format properties.
+func (t *T[B, P]) StringWithPropFormatter(
+	iFmt axisds.IntervalFormatter[B], propFmt func(P) string,
+) string {
+	t = t.atNow()
 	var b strings.Builder
 	var eh enumerateHelper[B, P]
 	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
 		eh.addRegion(rStart, rProp, t.propEq, func(start, end B, prop P) bool {
-			fmt.Fprintf(&b, "%s = %v\n", iFmt(start, end), prop)
+			fmt.Fprintf(&b, "%s = %s\n", iFmt(start, end), propFmt(prop))
 			return true
 		})
 		return true
@@ -426,8 +1520,33 @@ func (t *T[B, P]) String(iFmt axisds.IntervalFormatter[B]) string {
 	return b.String()
 }
 
+// DebugString formats the internal structure of the tree: every stored
+// boundary and its property, exactly as stored (including redundant boundaries
+// and boundaries with expired properties), along with B-tree statistics.
+// Redundant boundaries (with the same property as the previous boundary) are
+// marked.
+func (t *T[B, P]) DebugString(bFmt axisds.BoundaryFormatter[B]) string {
+	var b strings.Builder
+	fmt.Fprintf(
+		&b, "boundaries: %d, degree: %d, estimated depth: %d\n",
+		t.tree.Len(), t.opts.Degree, t.estimatedDepth(),
+	)
+	var lastProp P
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		fmt.Fprintf(&b, "  %s: %v", bFmt(rStart), rProp)
+		if t.propEq(rProp, lastProp) {
+			b.WriteString(" (redundant)")
+		}
+		b.WriteString("\n")
+		lastProp = rProp
+		return true
+	})
+	return b.String()
+}
+
 // CheckInvariants can be used in testing builds to verify internal invariants.
 func (t *T[B, P]) CheckInvariants() {
+	t = t.atNow()
 	var lastBoundary B
 	var lastProp P
 	lastBoundarySet := false
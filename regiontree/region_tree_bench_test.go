@@ -80,3 +80,35 @@ func benchRegionTree[B any](
 	}
 	fmt.Fprint(io.Discard, x)
 }
+
+// BenchmarkSequentialLookups compares point lookups at increasing positions
+// through a Cursor with independent RangeProperty calls.
+func BenchmarkSequentialLookups(b *testing.B) {
+	for _, regionLength := range []int{1, 10, 100} {
+		const nRegions = 10000
+		rt := Make[int, int](cmp.Compare[int], func(p1, p2 int) bool { return p1 == p2 })
+		for i := 0; i < nRegions; i++ {
+			rt.Set(i*regionLength, (i+1)*regionLength, i%2+1)
+		}
+		size := nRegions * regionLength
+		b.Run(fmt.Sprintf("region-length=%d", regionLength), func(b *testing.B) {
+			b.Run("cursor", func(b *testing.B) {
+				c := rt.NewCursor()
+				var x int
+				for i := 0; i < b.N; i++ {
+					x += c.PropertyAt(i % size)
+				}
+				fmt.Fprint(io.Discard, x)
+			})
+			b.Run("range-property", func(b *testing.B) {
+				var x int
+				for i := 0; i < b.N; i++ {
+					p := i % size
+					prop, _ := rt.RangeProperty(p, p+1)
+					x += prop
+				}
+				fmt.Fprint(io.Discard, x)
+			})
+		})
+	}
+}
@@ -18,8 +18,10 @@ import (
 	"bytes"
 	"cmp"
 	"fmt"
+	"maps"
 	"math/rand/v2"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -84,6 +86,43 @@ func testDataDriven[B Boundary](
 				rt.Update(start, end, func(v int) int { return 0 })
 			}
 
+		case "set":
+			for _, l := range strings.Split(strings.TrimSpace(td.Input), "\n") {
+				start, end, rem := axisds.MustParseIntervalPrefix(p, l)
+				var val int
+				if _, err := fmt.Sscanf(rem, "%d", &val); err != nil {
+					td.Fatalf(t, "invalid input %q: %v", l, err)
+				}
+				rt.Set(start, end, val)
+			}
+
+		case "delete":
+			for _, l := range strings.Split(strings.TrimSpace(td.Input), "\n") {
+				start, end := axisds.MustParseInterval(p, l)
+				rt.Delete(start, end)
+			}
+
+		case "any":
+			var val int
+			td.ScanArgs(t, "val", &val)
+			for _, l := range strings.Split(strings.TrimSpace(td.Input), "\n") {
+				start, end := axisds.MustParseInterval(p, l)
+				found := rt.Any(start, end, func(prop int) bool { return prop == val })
+				fmt.Fprintf(&buf, "%s: %t\n", l, found)
+			}
+			return buf.String()
+
+		case "compact":
+			before := rt.InternalLen()
+			rt.Compact()
+			fmt.Fprintf(&buf, "boundaries: %d -> %d\n", before, rt.InternalLen())
+
+		case "debug":
+			return rt.DebugString(axisds.MakeBoundaryFormatter[B]())
+
+		case "reset":
+			rt.Reset()
+
 		case "watermark":
 			var w int
 			td.ScanArgs(t, "w", &w)
@@ -105,6 +144,9 @@ func testDataDriven[B Boundary](
 }
 
 func TestRegionTreeRand(t *testing.T) {
+	// numOps is the number of cases in the operation switch below.
+	const numOps = 24
+
 	for test := 0; test < 100; test++ {
 		seed := rand.Uint64()
 		rng := rand.New(rand.NewPCG(seed, seed))
@@ -115,7 +157,11 @@ func TestRegionTreeRand(t *testing.T) {
 			fmt.Fprintf(&debugLog, "\nlog:\n")
 		}
 
-		rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+		rt := MakeWithOptions[int, int](
+			cmp.Compare[int],
+			func(a, b int) bool { return a == b },
+			Options[int, int]{Degree: 2 + rng.IntN(10), Validate: true},
+		)
 		n := naiveInts{}
 
 		valRange := rng.IntN(maxRange) + 1
@@ -128,10 +174,13 @@ func TestRegionTreeRand(t *testing.T) {
 				a, b = b, a
 			}
 
-			switch rng.IntN(10) {
+			switch rng.IntN(numOps) {
 			case 0:
 				delta := rng.IntN(10) - 5
-				rt.Update(a, b, func(p int) int { return p + delta })
+				changed := rt.Update(a, b, func(p int) int { return p + delta })
+				if expected := delta != 0 && a < b; changed != expected {
+					t.Fatalf("Update returned %t instead of %t\n%s", changed, expected, debugLog.String())
+				}
 				n.Add(a, b, delta)
 				if debug {
 					fmt.Fprintf(&debugLog, "[%d, %d) += %d\n", a, b, delta)
@@ -142,7 +191,10 @@ func TestRegionTreeRand(t *testing.T) {
 
 			case 1:
 				value := rng.IntN(10) - 5
-				rt.Update(a, b, func(p int) int { return value })
+				changed := rt.Update(a, b, func(p int) int { return value })
+				if expected := n.Any(a, b, func(p int) bool { return p != value }); changed != expected {
+					t.Fatalf("Update returned %t instead of %t\n%s", changed, expected, debugLog.String())
+				}
 				n.Set(a, b, value)
 				if debug {
 					fmt.Fprintf(&debugLog, "[%d, %d) = %d\n", a, b, value)
@@ -161,11 +213,299 @@ func TestRegionTreeRand(t *testing.T) {
 				}
 
 			case 3:
+				rt.Delete(a, b)
+				n.Set(a, b, 0)
+				if debug {
+					fmt.Fprintf(&debugLog, "delete [%d, %d)\n", a, b)
+				}
+
+			case 4:
 				if exp, actual := n.IsEmpty(), rt.IsEmpty(); exp != actual {
 					t.Fatalf("IsEmpty %t instead of %t\n%s", actual, exp, debugLog.String())
 				}
+				if exp, actual := n.IsEmpty(), rt.IsEmptyWithGC(); exp != actual {
+					t.Fatalf("IsEmptyWithGC %t instead of %t\n%s", actual, exp, debugLog.String())
+				}
+				if exp, actual := n.Len(), rt.Len(); exp != actual {
+					t.Fatalf("Len %d instead of %d\n%s", actual, exp, debugLog.String())
+				}
+				min, max, ok := rt.Bounds()
+				expMin, expMax, expOk := n.Bounds()
+				if min != expMin || max != expMax || ok != expOk {
+					t.Fatalf("Bounds %d %d %t instead of %d %d %t\n%s", min, max, ok, expMin, expMax, expOk, debugLog.String())
+				}
 
-			default:
+			case 5:
+				var lines []string
+				rt.EnumerateDescending(a, b, func(start, end, val int) bool {
+					lines = append(lines, fmt.Sprintf("  [%d, %d) = %d\n", start, end, val))
+					return true
+				})
+				slices.Reverse(lines)
+				var expected strings.Builder
+				n.Enumerate(a, b, func(start, end, val int) {
+					fmt.Fprintf(&expected, "  [%d, %d) = %d\n", start, end, val)
+				})
+				if actual := strings.Join(lines, ""); actual != expected.String() {
+					t.Fatalf("EnumerateDescending(%d,%d) mismatch:\n%sexpected:\n%s\n%s", a, b, actual, expected.String(), debugLog.String())
+				}
+
+			case 6:
+				actual := rt.ContainsRange(a, b)
+				expected := !n.Any(a, b, func(prop int) bool { return prop == 0 })
+				if actual != expected {
+					t.Fatalf("ContainsRange(%d,%d) mismatch: expected %t, got %t\n%s", a, b, expected, actual, debugLog.String())
+				}
+				actual = rt.Overlaps(a, b)
+				expected = n.Any(a, b, func(prop int) bool { return prop != 0 })
+				if actual != expected {
+					t.Fatalf("Overlaps(%d,%d) mismatch: expected %t, got %t\n%s", a, b, expected, actual, debugLog.String())
+				}
+
+			case 7:
+				var b1, b2 strings.Builder
+				rt.EnumerateGaps(a, b, func(start, end int) bool {
+					fmt.Fprintf(&b1, "  [%d, %d)\n", start, end)
+					return true
+				})
+				n.EnumerateGaps(a, b, func(start, end int) {
+					fmt.Fprintf(&b2, "  [%d, %d)\n", start, end)
+				})
+				if b1.String() != b2.String() {
+					t.Fatalf("EnumerateGaps(%d,%d) mismatch:\n%sexpected:\n%s\n%s", a, b, b1.String(), b2.String(), debugLog.String())
+				}
+
+			case 8:
+				changed := rt.UpdateWithSpan(a, b, func(rStart, rEnd, p int) int {
+					return p + (rEnd - rStart)
+				})
+				if expected := a < b; changed != expected {
+					t.Fatalf("UpdateWithSpan returned %t instead of %t\n%s", changed, expected, debugLog.String())
+				}
+				n.AddLength(a, b)
+				if debug {
+					fmt.Fprintf(&debugLog, "[%d, %d) += length\n", a, b)
+				}
+
+			case 9:
+				expectedAny := n.Any(a, b, func(p int) bool { return p > 0 })
+				expectedAll := !n.Any(a, b, func(p int) bool { return p <= 0 })
+				anyMatched, allMatched := rt.UpdateIf(a, b, func(p int) bool { return p > 0 }, func(p int) int { return p + 1 })
+				if anyMatched != expectedAny || allMatched != expectedAll {
+					t.Fatalf("UpdateIf returned %t, %t instead of %t, %t\n%s", anyMatched, allMatched, expectedAny, expectedAll, debugLog.String())
+				}
+				for i := a; i < b; i++ {
+					if n.values[i] > 0 {
+						n.values[i]++
+					}
+				}
+				if debug {
+					fmt.Fprintf(&debugLog, "[%d, %d) += 1 if positive\n", a, b)
+				}
+
+			case 10:
+				value := rng.IntN(10) - 5
+				rt.Set(a, b, value)
+				n.Set(a, b, value)
+				if debug {
+					fmt.Fprintf(&debugLog, "set [%d, %d) = %d\n", a, b, value)
+				}
+
+			case 11:
+				start, end, prop, ok := rt.SeekNonZero(a)
+				var expStart, expEnd, expProp int
+				expOk := false
+				n.Enumerate(a, maxRange, func(start, end, val int) {
+					if !expOk {
+						expStart, expEnd, expProp, expOk = start, end, val, true
+					}
+				})
+				if start != expStart || end != expEnd || prop != expProp || ok != expOk {
+					t.Fatalf("SeekNonZero(%d) = %d %d %d %t instead of %d %d %d %t\n%s",
+						a, start, end, prop, ok, expStart, expEnd, expProp, expOk, debugLog.String())
+				}
+
+			case 12:
+				start, end, prop, ok := rt.PrevNonZero(b)
+				var expStart, expEnd, expProp int
+				expOk := false
+				n.Enumerate(0, b, func(start, end, val int) {
+					expStart, expEnd, expProp, expOk = start, end, val, true
+				})
+				if start != expStart || end != expEnd || prop != expProp || ok != expOk {
+					t.Fatalf("PrevNonZero(%d) = %d %d %d %t instead of %d %d %d %t\n%s",
+						b, start, end, prop, ok, expStart, expEnd, expProp, expOk, debugLog.String())
+				}
+
+			case 13:
+				count := rng.IntN(5)
+				var all []Region[int, int]
+				n.Enumerate(0, maxRange, func(start, end, val int) {
+					all = append(all, Region[int, int]{Start: start, End: end, Prop: val})
+				})
+				k := min(count, len(all))
+				if actual, expected := rt.FirstN(count), all[:k]; !slices.Equal(actual, expected) {
+					t.Fatalf("FirstN(%d) = %v instead of %v\n%s", count, actual, expected, debugLog.String())
+				}
+				if actual, expected := rt.LastN(count), all[len(all)-k:]; !slices.Equal(actual, expected) {
+					t.Fatalf("LastN(%d) = %v instead of %v\n%s", count, actual, expected, debugLog.String())
+				}
+
+			case 14:
+				prop, ok := rt.RangeProperty(a, b)
+				expectedOk := a >= b || !slices.ContainsFunc(n.values[a:b], func(v int) bool { return v != n.values[a] })
+				if ok != expectedOk || (ok && a < b && prop != n.values[a]) {
+					t.Fatalf("RangeProperty(%d, %d) = %d, %t\n%s", a, b, prop, ok, debugLog.String())
+				}
+
+			case 15:
+				pred := func(p int) bool { return p >= 0 }
+				expected := a >= b || !slices.ContainsFunc(n.values[a:b], func(v int) bool { return !pred(v) })
+				if actual := rt.IsUniform(a, b, pred); actual != expected {
+					t.Fatalf("IsUniform(%d, %d) = %t\n%s", a, b, actual, debugLog.String())
+				}
+
+			case 16:
+				var expected []int
+				for i := a; i < b; i++ {
+					if (i == 0 && n.values[i] != 0) || (i > 0 && n.values[i] != n.values[i-1]) {
+						expected = append(expected, i)
+					}
+				}
+				if actual := slices.Collect(rt.Boundaries(a, b)); !slices.Equal(actual, expected) {
+					t.Fatalf("Boundaries(%d, %d) = %v instead of %v\n%s", a, b, actual, expected, debugLog.String())
+				}
+
+			case 17:
+				limit := rng.IntN(5)
+				var actual, expected []string
+				truncated := rt.EnumerateN(a, b, limit, func(start, end, val int) {
+					actual = append(actual, fmt.Sprintf("[%d, %d) = %d", start, end, val))
+				})
+				n.Enumerate(a, b, func(start, end, val int) {
+					expected = append(expected, fmt.Sprintf("[%d, %d) = %d", start, end, val))
+				})
+				expectedTruncated := len(expected) > limit
+				if expectedTruncated {
+					expected = expected[:limit]
+				}
+				if !slices.Equal(actual, expected) || truncated != expectedTruncated {
+					t.Fatalf("EnumerateN(%d, %d, %d) = %v, %t instead of %v, %t\n%s", a, b, limit, actual, truncated, expected, expectedTruncated, debugLog.String())
+				}
+
+			case 18:
+				value := rng.IntN(10) - 5
+				rStart, rEnd, prop, ok := rt.FindFirst(a, b, func(p int) bool { return p >= value })
+				var expected string
+				n.Enumerate(a, b, func(start, end, val int) {
+					if expected == "" && val >= value {
+						expected = fmt.Sprintf("[%d, %d) = %d", start, end, val)
+					}
+				})
+				if actual := fmt.Sprintf("[%d, %d) = %d", rStart, rEnd, prop); ok != (expected != "") || (ok && actual != expected) {
+					t.Fatalf("FindFirst(%d, %d, >= %d) = %s, %t instead of %s\n%s", a, b, value, actual, ok, expected, debugLog.String())
+				}
+
+			case 19:
+				value := rng.IntN(10) - 5
+				expected := 0
+				n.Enumerate(a, b, func(start, end, val int) {
+					if val >= value {
+						expected++
+					}
+				})
+				if actual := rt.CountWhere(a, b, func(p int) bool { return p >= value }); actual != expected {
+					t.Fatalf("CountWhere(%d, %d, >= %d) = %d instead of %d\n%s", a, b, value, actual, expected, debugLog.String())
+				}
+
+			case 20:
+				isBoundary := func(i int) bool {
+					return (i == 0 && n.values[i] != 0) || (i > 0 && n.values[i] != n.values[i-1])
+				}
+				expectedFloor, expectedFloorOk := a, false
+				for ; expectedFloor >= 0 && !expectedFloorOk; expectedFloor-- {
+					expectedFloorOk = isBoundary(expectedFloor)
+				}
+				expectedFloor++
+				if floor, ok := rt.FloorBoundary(a); ok != expectedFloorOk || (ok && floor != expectedFloor) {
+					t.Fatalf("FloorBoundary(%d) = %d, %t instead of %d, %t\n%s", a, floor, ok, expectedFloor, expectedFloorOk, debugLog.String())
+				}
+				expectedCeil, expectedCeilOk := a, false
+				for ; expectedCeil < maxRange && !expectedCeilOk; expectedCeil++ {
+					expectedCeilOk = isBoundary(expectedCeil)
+				}
+				expectedCeil--
+				if ceil, ok := rt.CeilingBoundary(a); ok != expectedCeilOk || (ok && ceil != expectedCeil) {
+					t.Fatalf("CeilingBoundary(%d) = %d, %t instead of %d, %t\n%s", a, ceil, ok, expectedCeil, expectedCeilOk, debugLog.String())
+				}
+
+			case 21:
+				// regionAt returns the maximal region with non-zero property containing
+				// i, in the naive representation.
+				regionAt := func(i int) string {
+					start, end := i, i+1
+					for start > 0 && n.values[start-1] == n.values[i] {
+						start--
+					}
+					for end < maxRange && n.values[end] == n.values[i] {
+						end++
+					}
+					return fmt.Sprintf("[%d, %d) = %d", start, end, n.values[i])
+				}
+				var expectedPrev, expectedCur, expectedNext string
+				before, after := a-1, a
+				if n.values[a] != 0 {
+					expectedCur = regionAt(a)
+					for before >= 0 && n.values[before] == n.values[a] {
+						before--
+					}
+					for after < maxRange && n.values[after] == n.values[a] {
+						after++
+					}
+				}
+				for before >= 0 && n.values[before] == 0 {
+					before--
+				}
+				if before >= 0 {
+					expectedPrev = regionAt(before)
+				}
+				for after < maxRange && n.values[after] == 0 {
+					after++
+				}
+				if after < maxRange {
+					expectedNext = regionAt(after)
+				}
+				str := func(r *Region[int, int]) string {
+					if r == nil {
+						return ""
+					}
+					return fmt.Sprintf("[%d, %d) = %d", r.Start, r.End, r.Prop)
+				}
+				prev, cur, next := rt.Neighbors(a)
+				if str(prev) != expectedPrev || str(cur) != expectedCur || str(next) != expectedNext {
+					t.Fatalf("Neighbors(%d) = %q, %q, %q instead of %q, %q, %q\n%s", a,
+						str(prev), str(cur), str(next), expectedPrev, expectedCur, expectedNext, debugLog.String())
+				}
+
+			case 22:
+				var actual, expected []string
+				rt.EnumerateWithGaps(a, b, func(start, end, val int) bool {
+					actual = append(actual, fmt.Sprintf("[%d, %d) = %d", start, end, val))
+					return true
+				})
+				for i := a; i < b; {
+					j := i + 1
+					for j < b && n.values[j] == n.values[i] {
+						j++
+					}
+					expected = append(expected, fmt.Sprintf("[%d, %d) = %d", i, j, n.values[i]))
+					i = j
+				}
+				if !slices.Equal(actual, expected) {
+					t.Fatalf("EnumerateWithGaps(%d, %d) = %v instead of %v\n%s", a, b, actual, expected, debugLog.String())
+				}
+
+			case 23:
 				var b1, b2 strings.Builder
 				withGC := rand.IntN(2) == 0
 				rt.enumerate(a, b, func(start, end, val int) bool {
@@ -178,6 +518,9 @@ func TestRegionTreeRand(t *testing.T) {
 				if b1.String() != b2.String() {
 					t.Fatalf("Enumerate(%d,%d) mismatch:\n%sexpected:\n%s\n%s", a, b, b1.String(), b2.String(), debugLog.String())
 				}
+
+			default:
+				panic("numOps does not match the number of cases")
 			}
 
 			rt.CheckInvariants()
@@ -197,6 +540,19 @@ func (n *naiveInts) Add(start int, end int, delta int) {
 	}
 }
 
+// AddLength adds the length of each region (maximal run of equal values)
+// within [start, end) to its value.
+func (n *naiveInts) AddLength(start int, end int) {
+	for i := start; i < end; {
+		j := i + 1
+		for j < end && n.values[j] == n.values[i] {
+			j++
+		}
+		n.Add(i, j, j-i)
+		i = j
+	}
+}
+
 func (n *naiveInts) Set(start int, end int, value int) {
 	for i := start; i < end; i++ {
 		n.values[i] = value
@@ -223,6 +579,23 @@ func (n *naiveInts) Enumerate(start int, end int, emit func(start, end, val int)
 	}
 }
 
+func (n *naiveInts) EnumerateGaps(start int, end int, emit func(start, end int)) {
+	gapStart := -1
+	for i := start; i < end; i++ {
+		if n.values[i] == 0 {
+			if gapStart == -1 {
+				gapStart = i
+			}
+		} else if gapStart != -1 {
+			emit(gapStart, i)
+			gapStart = -1
+		}
+	}
+	if gapStart != -1 {
+		emit(gapStart, end)
+	}
+}
+
 func (n *naiveInts) Any(start int, end int, fn func(int) bool) bool {
 	for i := start; i < end; i++ {
 		if fn(n.values[i]) {
@@ -232,6 +605,22 @@ func (n *naiveInts) Any(start int, end int, fn func(int) bool) bool {
 	return false
 }
 
+func (n *naiveInts) Len() int {
+	count := 0
+	n.Enumerate(0, maxRange, func(start, end, val int) { count++ })
+	return count
+}
+
+func (n *naiveInts) Bounds() (min, max int, ok bool) {
+	n.Enumerate(0, maxRange, func(start, end, val int) {
+		if !ok {
+			min, ok = start, true
+		}
+		max = end
+	})
+	return min, max, ok
+}
+
 func (n *naiveInts) IsEmpty() bool {
 	for i := range n.values {
 		if n.values[i] != 0 {
@@ -269,4 +658,155 @@ func TestClone(t *testing.T) {
 	t1.Update(3, 8, func(v int) int { return 300 })
 	expect(&t1, 3, 8, 300, 8, 9, 100, 9, 22, 200)
 	expect(&t2, 5, 6, 100, 10, 22, 200)
+
+	t3 := t1.Clone()
+	t3.Materialize()
+	expect(&t3, 3, 8, 300, 8, 9, 100, 9, 22, 200)
+	t3.Update(0, 100, func(v int) int { return 1 })
+	expect(&t1, 3, 8, 300, 8, 9, 100, 9, 22, 200)
+	expect(&t3, 0, 100, 1)
+}
+
+func TestShift(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		delta := rng.IntN(100)
+		rt2 := rt.Clone()
+		rt2.Shift(func(b int) int { return b + delta })
+		rt2.CheckInvariants()
+		context := fmt.Sprintf("shift by %d\nseed: %d", delta, seed)
+		checkEqual(t, &rt, &n, context)
+		var n2 naiveInts
+		copy(n2.values[delta:], n.values[:])
+		checkEqual(t, &rt2, &n2, context)
+	}
+}
+
+func TestStringWithPropFormatter(t *testing.T) {
+	rt := Make[int, map[string]int](cmp.Compare[int], func(a, b map[string]int) bool { return maps.Equal(a, b) })
+	rt.Set(1, 5, map[string]int{"a": 1, "b": 2})
+	rt.Set(5, 8, map[string]int{"c": 3})
+	propFmt := func(m map[string]int) string {
+		return fmt.Sprintf("%d keys", len(m))
+	}
+	expected := "[1, 5) = 2 keys\n[5, 8) = 1 keys\n"
+	if actual := rt.StringWithPropFormatter(intervalFmt, propFmt); actual != expected {
+		t.Fatalf("expected:\n%sgot:\n%s", expected, actual)
+	}
+}
+
+// TestReadOnlyMethods verifies that read-only methods don't modify the tree,
+// even when there are boundaries that could be removed.
+func TestReadOnlyMethods(t *testing.T) {
+	lowWatermark := 0
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool {
+		return a == b || (a < lowWatermark && b < lowWatermark)
+	})
+	rt.Set(10, 20, 10)
+	rt.Set(20, 30, 20)
+	rt.Set(40, 50, 30)
+	lowWatermark = 15
+	before := rt.DebugString(axisds.MakeBoundaryFormatter[int]())
+
+	rt.Enumerate(0, 100, func(start, end, prop int) bool { return true })
+	rt.EnumerateAll(func(start, end, prop int) bool { return true })
+	rt.Any(0, 100, func(prop int) bool { return false })
+	if rt.IsEmpty() {
+		t.Fatalf("expected non-empty tree")
+	}
+	if after := rt.DebugString(axisds.MakeBoundaryFormatter[int]()); after != before {
+		t.Fatalf("tree modified:\n%s\nbefore:\n%s", after, before)
+	}
+
+	rt.Compact()
+	if rt.InternalLen() != 4 {
+		t.Fatalf("unexpected tree after Compact:\n%s", rt.DebugString(axisds.MakeBoundaryFormatter[int]()))
+	}
+}
+
+func TestEnumeratePage(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		start, end := rng.IntN(valRange), valRange
+		for numPages := 0; ; numPages++ {
+			if numPages > maxRange {
+				t.Fatalf("too many pages; seed: %d", seed)
+			}
+			limit := rng.IntN(5) + 1
+			var actual, expected []string
+			next, more := rt.EnumeratePage(start, end, limit, func(start, end, val int) {
+				actual = append(actual, fmt.Sprintf("[%d, %d) = %d", start, end, val))
+			})
+			n.Enumerate(start, end, func(start, end, val int) {
+				expected = append(expected, fmt.Sprintf("[%d, %d) = %d", start, end, val))
+			})
+			if expectedMore := len(expected) > limit; more != expectedMore {
+				t.Fatalf("expected more=%t; seed: %d", expectedMore, seed)
+			}
+			if more {
+				expected = expected[:limit]
+			}
+			if !slices.Equal(actual, expected) {
+				t.Fatalf("page starting at %d:\n%v\nexpected:\n%v\nseed: %d", start, actual, expected, seed)
+			}
+			if !more {
+				break
+			}
+			start = next
+			// Modify the tree between pages.
+			if rng.IntN(2) == 0 {
+				a, b := rng.IntN(valRange), rng.IntN(valRange)
+				if a > b {
+					a, b = b, a
+				}
+				value := rng.IntN(5)
+				rt.Set(a, b, value)
+				n.Set(a, b, value)
+			}
+		}
+	}
+}
+
+func TestEnumerateNNegative(t *testing.T) {
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	rt.Set(1, 10, 1)
+	if !rt.EnumerateN(0, 100, 0, func(start, end, val int) {
+		t.Fatalf("unexpected region")
+	}) {
+		t.Fatalf("expected truncated result")
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	rt.EnumerateN(0, 100, -1, func(start, end, val int) {})
+}
+
+func TestAppendRegions(t *testing.T) {
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	for i := 0; i < 100; i++ {
+		rt.Set(i*10, i*10+5, i+1)
+	}
+	buf := rt.AppendRegions(nil, 3, 22)
+	if res, expected := fmt.Sprint(buf), "[{3 5 1} {10 15 2} {20 22 3}]"; res != expected {
+		t.Fatalf("expected %s, got %s", expected, res)
+	}
+	buf = rt.AppendRegions(buf, 992, 2000)
+	if res, expected := fmt.Sprint(buf), "[{3 5 1} {10 15 2} {20 22 3} {992 995 100}]"; res != expected {
+		t.Fatalf("expected %s, got %s", expected, res)
+	}
+	buf = make([]Region[int, int], 0, 100)
+	allocs := testing.AllocsPerRun(10, func() {
+		buf = rt.AppendRegions(buf[:0], 0, 1000)
+	})
+	if allocs != 0 || len(buf) != 100 {
+		t.Fatalf("expected no allocations and 100 regions (%v allocations, %d regions)", allocs, len(buf))
+	}
 }
@@ -0,0 +1,110 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render contains helpers for rendering region trees as images, for
+// debugging.
+package render
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"io"
+
+	"github.com/RaduBerinde/axisds"
+	"github.com/RaduBerinde/axisds/regiontree"
+)
+
+// Options for WriteSVG.
+type Options[B regiontree.Boundary, P regiontree.Property] struct {
+	// Measure is used to map boundaries to horizontal positions. Required.
+	Measure regiontree.MeasureFn[B]
+
+	// Width and Height of the image, in pixels. If zero, 800 and 30 are used.
+	Width, Height int
+
+	// IntervalFormatter is used for the hover labels. If nil, boundaries are
+	// formatted using fmt.Sprint().
+	IntervalFormatter axisds.IntervalFormatter[B]
+
+	// PropFormatter is used for the hover labels. If nil, fmt.Sprint() is used.
+	// Regions with the same formatted property have the same color.
+	PropFormatter func(P) string
+}
+
+// WriteSVG writes an SVG timeline of the regions of t in [start, end): each
+// region with non-zero property is drawn as a colored rectangle with a hover
+// label showing the interval and the property. The SVG can be embedded
+// directly in HTML pages.
+func WriteSVG[B regiontree.Boundary, P regiontree.Property](
+	w io.Writer, t *regiontree.T[B, P], start, end B, opts Options[B, P],
+) error {
+	if opts.Measure == nil {
+		panic("Measure is required")
+	}
+	width, height := opts.Width, opts.Height
+	if width == 0 {
+		width = 800
+	}
+	if height == 0 {
+		height = 30
+	}
+	iFmt := opts.IntervalFormatter
+	if iFmt == nil {
+		iFmt = axisds.MakeIntervalFormatter(axisds.MakeBoundaryFormatter[B]())
+	}
+	propFmt := opts.PropFormatter
+	if propFmt == nil {
+		propFmt = func(p P) string { return fmt.Sprint(p) }
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`+"\n", width, height)
+	fmt.Fprintf(bw, `<rect x="0" y="0" width="%d" height="%d" fill="#eeeeee"><title>`, width, height)
+	writeEscaped(bw, iFmt(start, end))
+	bw.WriteString("</title></rect>\n")
+
+	total := opts.Measure(start, end)
+	if total > 0 {
+		scale := float64(width) / total
+		t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+			label := propFmt(prop)
+			x := opts.Measure(start, rStart) * scale
+			fmt.Fprintf(
+				bw, `<rect x="%.2f" y="0" width="%.2f" height="%d" fill="%s"><title>`,
+				x, opts.Measure(rStart, rEnd)*scale, height, color(label),
+			)
+			writeEscaped(bw, iFmt(rStart, rEnd)+" = "+label)
+			bw.WriteString("</title></rect>\n")
+			return true
+		})
+	}
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+// writeEscaped writes the string, escaped for use as XML text.
+func writeEscaped(w io.Writer, s string) {
+	// EscapeText can only fail if the writer fails, in which case the error is
+	// reported by Flush.
+	_ = xml.EscapeText(w, []byte(s))
+}
+
+// color returns a color derived from the given label.
+func color(label string) string {
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	return fmt.Sprintf("hsl(%d, 60%%, 55%%)", h.Sum32()%360)
+}
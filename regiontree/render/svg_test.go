@@ -0,0 +1,66 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/RaduBerinde/axisds/regiontree"
+)
+
+func TestWriteSVG(t *testing.T) {
+	rt := regiontree.Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	rt.Set(0, 10, 1)
+	rt.Set(20, 30, 2)
+	rt.Set(30, 40, 1)
+
+	var buf strings.Builder
+	err := WriteSVG(&buf, &rt, 0, 50, Options[int, int]{
+		Measure:       func(start, end int) float64 { return float64(end - start) },
+		Width:         500,
+		PropFormatter: func(p int) string { return fmt.Sprintf("<%d>", p) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	// Verify that the output is well-formed XML.
+	var v struct {
+		Rects []struct {
+			X     string `xml:"x,attr"`
+			Width string `xml:"width,attr"`
+			Fill  string `xml:"fill,attr"`
+			Title string `xml:"title"`
+		} `xml:"rect"`
+	}
+	if err := xml.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out)
+	}
+	if len(v.Rects) != 4 {
+		t.Fatalf("expected 4 rects:\n%s", out)
+	}
+	r := v.Rects[2]
+	if r.X != "200.00" || r.Width != "100.00" || r.Title != "[20, 30) = <2>" {
+		t.Fatalf("unexpected rect %+v:\n%s", r, out)
+	}
+	if v.Rects[1].Fill != v.Rects[3].Fill || v.Rects[1].Fill == v.Rects[2].Fill {
+		t.Fatalf("unexpected colors:\n%s", out)
+	}
+}
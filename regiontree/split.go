@@ -0,0 +1,183 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import "github.com/RaduBerinde/btreemap"
+
+// SplitAt returns two new trees: one with the regions below b and one with the
+// regions at or above b. A region that contains b is split between the two
+// trees. The receiver is not modified.
+//
+// The new trees are lazy clones of t (see Clone), so any part of the internal
+// structure that is not modified remains shared. The runtime complexity is
+// O(N log N) in the worst case, because the boundaries that don't belong in each
+// of the new trees have to be removed.
+func (t *T[B, P]) SplitAt(b B) (left, right T[B, P]) {
+	b = t.inputBoundary(b)
+	left = t.Clone()
+	left.clearFrom(b)
+	right = t.Clone()
+	right.clearBefore(b)
+	return left, right
+}
+
+// Truncate sets the property to zero for everything outside [start, end).
+//
+// The runtime complexity is O((K + 1) log N) where K is the number of regions
+// outside the range.
+func (t *T[B, P]) Truncate(start, end B) {
+	defer t.endOp(t.startOp())
+	start, end = t.inputBoundary(start), t.inputBoundary(end)
+	if t.cmp(start, end) >= 0 {
+		t.Reset()
+		return
+	}
+	t.clearBefore(start)
+	t.clearFrom(end)
+}
+
+// SetLowWatermark discards all the regions below b (setting the property to
+// zero) and remembers b as the low watermark: subsequent modifications are
+// clamped to the watermark, so everything below it stays zero (e.g. a Set of a
+// range that straddles the watermark only affects the part above it). This is
+// useful when the boundaries are times and old information is no longer
+// needed.
+//
+// The watermark only increases: a b below the current watermark has no effect.
+// The watermark is inherited by clones and is moved by Shift.
+//
+// The runtime complexity is O((K + 1) log N) where K is the number of
+// boundaries below b; since each boundary is discarded at most once, this is
+// O(log N) amortized.
+func (t *T[B, P]) SetLowWatermark(b B) {
+	defer t.endOp(t.startOp())
+	b = t.inputBoundary(b)
+	if t.hasLowWatermark && t.cmp(b, t.lowWatermark) <= 0 {
+		return
+	}
+	t.clearBefore(b)
+	t.lowWatermark, t.hasLowWatermark = b, true
+}
+
+// LowWatermark returns the low watermark set by SetLowWatermark, if any.
+func (t *T[B, P]) LowWatermark() (_ B, ok bool) {
+	return t.lowWatermark, t.hasLowWatermark
+}
+
+// aboveWatermark clamps a boundary passed to a modification that can add
+// regions, so that nothing is added below the low watermark (see
+// SetLowWatermark).
+func (t *T[B, P]) aboveWatermark(b B) B {
+	if t.hasLowWatermark && t.cmp(b, t.lowWatermark) < 0 {
+		return t.lowWatermark
+	}
+	return b
+}
+
+// Excise removes the regions in [start, end) from the tree (setting the
+// property to zero) and returns a new tree which contains exactly the removed
+// regions.
+//
+// The runtime complexity is O(log N + K) where K is the number of regions in
+// the range.
+func (t *T[B, P]) Excise(start, end B) T[B, P] {
+	defer t.endOp(t.startOp())
+	start, end = t.inputBoundary(start), t.inputBoundary(end)
+	b := t.newBuilder()
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		b.Append(rStart, rEnd, prop)
+		return true
+	})
+	t.Delete(start, end)
+	return b.Finish()
+}
+
+// CloneRange returns a new tree which contains only the regions in
+// [start, end) (regions that straddle start or end are truncated). The
+// receiver is not modified.
+//
+// The new tree is a lazy clone of t (see Clone) with the regions outside the
+// range removed, so any part of the internal structure that is not modified
+// remains shared. The runtime complexity is O((K + 1) log N) where K is the
+// number of regions outside the range.
+//
+// If Options.CloneProp is set, the properties in the range are copied and the
+// new tree does not share any structure with t; the runtime complexity is then
+// O(log N + K log K) where K is the number of regions in the range.
+func (t *T[B, P]) CloneRange(start, end B) T[B, P] {
+	t = t.atNow()
+	start, end = t.inputBoundary(start), t.inputBoundary(end)
+	if t.opts.CloneProp == nil && t.cmp(start, end) < 0 {
+		c := t.Clone()
+		c.clearBefore(start)
+		c.clearFrom(end)
+		return c
+	}
+	b := t.newBuilder()
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		if t.opts.CloneProp != nil {
+			prop = t.opts.CloneProp(prop)
+		}
+		b.Append(rStart, rEnd, prop)
+		return true
+	})
+	return b.Finish()
+}
+
+// clearBefore sets the property to zero for everything below b.
+func (t *T[B, P]) clearBefore(b B) {
+	defer t.endOp(t.startOp())
+	t.recordSnapshot()
+	if start, _, ok := t.Bounds(); ok && t.opts.OnChange != nil {
+		t.notifyDelete(start, b)
+	}
+	_, prop := t.endBoundaryInfo(b)
+	var toDelete []B
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.LT(b), func(rStart B, rProp P) bool {
+		toDelete = append(toDelete, rStart)
+		return true
+	})
+	for _, r := range toDelete {
+		t.tree.Delete(r)
+	}
+	var zeroProp P
+	if t.propEq(prop, zeroProp) {
+		t.tree.Delete(b)
+	} else {
+		t.tree.ReplaceOrInsert(b, prop)
+	}
+}
+
+// clearFrom sets the property to zero for everything at or above b.
+func (t *T[B, P]) clearFrom(b B) {
+	defer t.endOp(t.startOp())
+	t.recordSnapshot()
+	if _, end, ok := t.Bounds(); ok && t.opts.OnChange != nil {
+		t.notifyDelete(b, end)
+	}
+	_, beforeProp := t.startBoundaryInfo(b)
+	var toDelete []B
+	t.tree.AscendFunc(btreemap.GE(b), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		toDelete = append(toDelete, rStart)
+		return true
+	})
+	for _, r := range toDelete {
+		t.tree.Delete(r)
+	}
+	var zeroProp P
+	if !t.propEq(beforeProp, zeroProp) {
+		t.tree.ReplaceOrInsert(b, zeroProp)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSplitAt(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		b := rng.IntN(valRange + 1)
+		left, right := rt.SplitAt(b)
+		left.CheckInvariants()
+		right.CheckInvariants()
+		context := fmt.Sprintf("split at %d\nseed: %d", b, seed)
+		checkEqual(t, &rt, &n, context)
+		nLeft, nRight := n, n
+		nLeft.Set(b, maxRange, 0)
+		nRight.Set(0, b, 0)
+		checkEqual(t, &left, &nLeft, context)
+		checkEqual(t, &right, &nRight, context)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		a, b := rng.IntN(valRange+1), rng.IntN(valRange+1)
+		rt.Truncate(a, b)
+		rt.CheckInvariants()
+		if a < b {
+			n.Set(0, a, 0)
+			n.Set(b, maxRange, 0)
+		} else {
+			n.Set(0, maxRange, 0)
+		}
+		checkEqual(t, &rt, &n, fmt.Sprintf("truncate to [%d, %d)\nseed: %d", a, b, seed))
+	}
+}
+
+func TestSetLowWatermark(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		watermark := 0
+		for b := 0; b < valRange; b += rng.IntN(10) + 1 {
+			rt.SetLowWatermark(b)
+			rt.CheckInvariants()
+			watermark = b
+			n.Set(0, b, 0)
+			checkEqual(t, &rt, &n, fmt.Sprintf("watermark %d\nseed: %d", b, seed))
+
+			// Modifications below the watermark are clamped.
+			x, y := rng.IntN(valRange), rng.IntN(valRange)
+			if x > y {
+				x, y = y, x
+			}
+			value := rng.IntN(5) + 1
+			if rng.IntN(2) == 0 {
+				rt.Set(x, y, value)
+				n.Set(max(x, watermark), y, value)
+			} else {
+				rt.Update(x, y, func(p int) int { return p + value })
+				n.Add(max(x, watermark), y, value)
+			}
+			rt.CheckInvariants()
+			context := fmt.Sprintf("watermark %d, modify [%d, %d)\nseed: %d", b, x, y, seed)
+			checkEqual(t, &rt, &n, context)
+			if wm, ok := rt.LowWatermark(); !ok || wm != watermark {
+				t.Fatalf("LowWatermark() = %d, %t\n%s", wm, ok, context)
+			}
+		}
+	}
+
+	// A lower watermark has no effect, and Rollback restores the watermark.
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	rt.Set(0, 100, 1)
+	rt.SetLowWatermark(50)
+	rt.SetLowWatermark(20)
+	rt.Checkpoint()
+	rt.SetLowWatermark(80)
+	rt.Rollback()
+	rt.Set(0, 100, 2)
+	if actual, expected := rt.String(intervalFmt), "[50, 100) = 2\n"; actual != expected {
+		t.Fatalf("expected:\n%sgot:\n%s", expected, actual)
+	}
+}
+
+func TestExcise(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		a, b := rng.IntN(valRange+1), rng.IntN(valRange+1)
+		excised := rt.Excise(a, b)
+		rt.CheckInvariants()
+		excised.CheckInvariants()
+		var nExcised naiveInts
+		for i := a; i < b; i++ {
+			nExcised.values[i] = n.values[i]
+			n.values[i] = 0
+		}
+		context := fmt.Sprintf("excise [%d, %d)\nseed: %d", a, b, seed)
+		checkEqual(t, &rt, &n, context)
+		checkEqual(t, &excised, &nExcised, context)
+	}
+}
+
+func TestCloneRange(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		a, b := rng.IntN(valRange+1), rng.IntN(valRange+1)
+		c := rt.CloneRange(a, b)
+		c.CheckInvariants()
+		var nc naiveInts
+		for i := a; i < b; i++ {
+			nc.values[i] = n.values[i]
+		}
+		context := fmt.Sprintf("clone [%d, %d)\nseed: %d", a, b, seed)
+		checkEqual(t, &c, &nc, context)
+		// Modifying the clone must not affect the original.
+		c.Update(0, maxRange, func(p int) int { return p + 1 })
+		checkEqual(t, &rt, &n, context)
+	}
+}
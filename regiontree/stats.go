@@ -0,0 +1,99 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import "unsafe"
+
+// Stats contains statistics about a region tree.
+type Stats struct {
+	// Regions is the number of regions with non-zero property (see Len).
+	Regions int
+	// Boundaries is the number of boundaries stored internally (see
+	// InternalLen).
+	Boundaries int
+	// EstimatedNodes is an estimate of the number of internal B-tree nodes. The
+	// B-tree package does not expose its nodes, so the exact count is not
+	// available; the estimate assumes that nodes are on average 3/4 full.
+	EstimatedNodes int
+	// EstimatedDepth is an estimate of the depth of the B-tree (under the same
+	// assumption as EstimatedNodes).
+	EstimatedDepth int
+	// EstimatedBytes is an estimate of the heap memory used by the B-tree
+	// nodes, based on EstimatedNodes and on the layout of the nodes (a node
+	// holds its boundaries and properties inline, in an array with room for
+	// 2*Degree-1 entries). It does not include any memory referenced by the
+	// boundaries or the properties (e.g. the contents of a slice).
+	EstimatedBytes int
+}
+
+// Stats returns statistics about the tree.
+//
+// Counting the regions requires walking the tree, so the runtime complexity is
+// O(N); the other statistics are computed in O(log N).
+func (t *T[B, P]) Stats() Stats {
+	s := Stats{
+		Regions:    t.Len(),
+		Boundaries: t.InternalLen(),
+	}
+	if s.Boundaries == 0 {
+		return s
+	}
+	maxItems := 2*t.opts.Degree - 1
+	avgItems := t.estimatedAvgItems()
+	s.EstimatedNodes = (s.Boundaries + avgItems - 1) / avgItems
+	s.EstimatedDepth = t.estimatedDepth()
+
+	// Each node has a header and an items array; internal nodes also have a
+	// children array.
+	const ptrSize = int(unsafe.Sizeof(uintptr(0)))
+	nodeHeaderSize := int(unsafe.Sizeof(btreeNode[B, P]{}))
+	itemSize := int(unsafe.Sizeof(boundaryProp[B, P]{}))
+	s.EstimatedBytes = s.EstimatedNodes * (nodeHeaderSize + maxItems*itemSize)
+	if internalNodes := s.EstimatedNodes / (avgItems + 1); internalNodes > 0 {
+		s.EstimatedBytes += internalNodes * (maxItems + 1) * ptrSize
+	}
+	return s
+}
+
+// estimatedAvgItems returns the estimated average number of boundaries in a
+// B-tree node, assuming that nodes are on average 3/4 full.
+func (t *T[B, P]) estimatedAvgItems() int {
+	maxItems := 2*t.opts.Degree - 1
+	return (maxItems*3 + 3) / 4
+}
+
+// estimatedDepth returns an estimate of the depth of the B-tree.
+func (t *T[B, P]) estimatedDepth() int {
+	n := t.tree.Len()
+	if n == 0 {
+		return 0
+	}
+	avgItems := t.estimatedAvgItems()
+	depth := 1
+	// Each level of internal nodes multiplies the capacity by the average
+	// number of children.
+	for capacity := avgItems; capacity < n; capacity *= avgItems + 1 {
+		depth++
+	}
+	return depth
+}
+
+// btreeNode mirrors the layout of a btreemap node: the items (each a key and
+// a value), the child pointers, and the copy-on-write context pointer.
+type btreeNode[B Boundary, P Property] struct {
+	items    []boundaryProp[B, P]
+	children []unsafe.Pointer
+	cow      unsafe.Pointer
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	if s := rt.Stats(); s != (Stats{}) {
+		t.Fatalf("unexpected stats for empty tree: %+v", s)
+	}
+	for i := 0; i < 1000; i++ {
+		rt.Set(i*10, i*10+5, i+1)
+	}
+	s := rt.Stats()
+	if s.Regions != 1000 || s.Boundaries != 2000 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	if s.EstimatedNodes < 2000/15 || s.EstimatedNodes > 2000/7 {
+		t.Fatalf("unexpected node estimate: %+v", s)
+	}
+	if s.EstimatedDepth != 3 {
+		t.Fatalf("unexpected depth estimate: %+v", s)
+	}
+	if s.EstimatedBytes < 2000*16 {
+		t.Fatalf("unexpected bytes estimate: %+v", s)
+	}
+}
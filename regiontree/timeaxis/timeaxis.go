@@ -0,0 +1,72 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timeaxis contains helpers for region trees with time.Time
+// boundaries.
+package timeaxis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/RaduBerinde/axisds"
+	"github.com/RaduBerinde/axisds/regiontree"
+)
+
+// Compare is an axisds.CompareFn[time.Time]. Times are compared as instants, so
+// the same instant in different locations is the same boundary.
+func Compare(x, y time.Time) int {
+	return x.Compare(y)
+}
+
+var _ axisds.CompareFn[time.Time] = Compare
+
+// Make creates a new region tree with time.Time boundaries.
+func Make[P regiontree.Property](propEq regiontree.PropertyEqualFn[P]) regiontree.T[time.Time, P] {
+	return regiontree.Make[time.Time, P](Compare, propEq)
+}
+
+// MakeFormatter creates a BoundaryFormatter[time.Time] that uses the RFC3339
+// format with sub-second precision (trailing zeros are omitted).
+func MakeFormatter() axisds.BoundaryFormatter[time.Time] {
+	return func(b time.Time) string {
+		return b.Format(time.RFC3339Nano)
+	}
+}
+
+// MakeParser creates a Parser[time.Time] for the RFC3339 format (with optional
+// sub-second precision); it is the counterpart of MakeFormatter.
+func MakeParser() axisds.Parser[time.Time] {
+	return axisds.MakeFuncParser(parseBoundary)
+}
+
+// Measure returns a MeasureFn[time.Time] which measures intervals in the given
+// unit; for example, Measure(time.Second) returns the duration of an interval
+// in seconds.
+func Measure(unit time.Duration) regiontree.MeasureFn[time.Time] {
+	if unit <= 0 {
+		panic("unit must be positive")
+	}
+	return func(start, end time.Time) float64 {
+		return float64(end.Sub(start)) / float64(unit)
+	}
+}
+
+func parseBoundary(str string) (time.Time, error) {
+	b, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("malformed boundary %q: %v", str, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeaxis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RaduBerinde/axisds"
+)
+
+func TestTimeAxis(t *testing.T) {
+	rt := Make[int](func(a, b int) bool { return a == b })
+	p := MakeParser()
+	iFmt := axisds.MakeIntervalFormatter(MakeFormatter())
+
+	start, end, rem := axisds.MustParseIntervalPrefix(p, "[2025-01-01T00:00:00Z, 2025-01-01T00:00:01.5Z) foo")
+	if rem != "foo" {
+		t.Fatalf("incorrect remainder %q", rem)
+	}
+	rt.Set(start, end, 1)
+	// The same instant in a different location.
+	loc := time.FixedZone("X", 3600)
+	rt.Set(time.Date(2025, 1, 1, 1, 0, 1, 500_000_000, loc), time.Date(2025, 1, 1, 1, 0, 3, 0, loc), 2)
+
+	var regions []string
+	rt.Enumerate(start, start.Add(time.Hour), func(rStart, rEnd time.Time, prop int) bool {
+		regions = append(regions, iFmt(rStart.UTC(), rEnd.UTC()))
+		return true
+	})
+	expected := []string{
+		"[2025-01-01T00:00:00Z, 2025-01-01T00:00:01.5Z)",
+		"[2025-01-01T00:00:01.5Z, 2025-01-01T00:00:03Z)",
+	}
+	if len(regions) != len(expected) || regions[0] != expected[0] || regions[1] != expected[1] {
+		t.Fatalf("incorrect regions %v", regions)
+	}
+
+	if l := rt.CoveredLength(start, start.Add(time.Hour), Measure(time.Millisecond)); l != 3000 {
+		t.Fatalf("incorrect covered length %v", l)
+	}
+
+	if _, err := p.ParseBoundary("2025-01-01"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
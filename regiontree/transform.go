@@ -0,0 +1,151 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"github.com/RaduBerinde/axisds"
+	"github.com/RaduBerinde/btreemap"
+)
+
+// MapBoundaries creates a new tree with the same regions as t, with the
+// boundaries transformed by fn. The function must be monotonic (non-decreasing)
+// with respect to the two compare functions; MapBoundaries panics otherwise.
+// If fn maps two boundaries to the same value, the region between them
+// disappears. The receiver is not modified, and the new tree does not inherit
+// its options (other than Degree).
+//
+// The runtime complexity is O(N log N).
+func MapBoundaries[B, B2 Boundary, P Property](
+	t *T[B, P], fn func(b B) B2, cmp2 axisds.CompareFn[B2],
+) T[B2, P] {
+	t = t.atNow()
+	var mapped []boundaryProp[B2, P]
+	t.tree.AscendFunc(btreemap.Min[B](), btreemap.Max[B](), func(rStart B, rProp P) bool {
+		b := fn(rStart)
+		if n := len(mapped); n > 0 {
+			switch c := cmp2(mapped[n-1].b, b); {
+			case c > 0:
+				panic("boundary function is not monotonic")
+			case c == 0:
+				// The previous region is now empty.
+				mapped[n-1].prop = t.normalize(rProp)
+				return true
+			}
+		}
+		mapped = append(mapped, boundaryProp[B2, P]{b: b, prop: t.normalize(rProp)})
+		return true
+	})
+
+	res := MakeWithOptions(cmp2, t.eq, Options[B2, P]{Degree: t.opts.Degree})
+	var lastProp P
+	for _, r := range mapped {
+		if !t.propEq(r.prop, lastProp) {
+			res.tree.ReplaceOrInsert(r.b, r.prop)
+			lastProp = r.prop
+		}
+	}
+	return res
+}
+
+// MapProperties creates a new tree with the same regions as t, with the
+// non-zero properties transformed by fn. Regions with zero property remain
+// zero. Neighboring regions which end up with equal properties (according to
+// eq2) are merged.
+//
+// The runtime complexity is O(N log N).
+func MapProperties[B Boundary, P, P2 Property](
+	t *T[B, P], fn func(p P) P2, eq2 PropertyEqualFn[P2],
+) T[B, P2] {
+	b := Builder[B, P2]{t: MakeWithOptions(t.cmp, eq2, Options[B, P2]{Degree: t.opts.Degree})}
+	t.EnumerateAll(func(start, end B, prop P) bool {
+		b.Append(start, end, fn(prop))
+		return true
+	})
+	return b.Finish()
+}
+
+// FilterWhere creates a new tree which contains only the regions of t with
+// properties that satisfy the given predicate.
+//
+// The runtime complexity is O(N log N).
+func (t *T[B, P]) FilterWhere(pred func(p P) bool) T[B, P] {
+	t = t.atNow()
+	b := t.newBuilder()
+	t.EnumerateAll(func(start, end B, prop P) bool {
+		if pred(prop) {
+			b.Append(start, end, prop)
+		}
+		return true
+	})
+	return b.Finish()
+}
+
+// DeleteWhere sets the property to zero for all regions with properties that
+// satisfy the given predicate.
+//
+// The tree is traversed in a single pass; the runtime complexity is O(N) plus
+// O(log N) for each boundary that changes.
+func (t *T[B, P]) DeleteWhere(pred func(p P) bool) {
+	var zeroProp P
+	t.updateAll(func(p P) P {
+		if pred(p) {
+			return zeroProp
+		}
+		return p
+	})
+}
+
+// ReplaceWhere applies fn to the properties of all regions with properties
+// that satisfy the given predicate. Regions with zero property are not
+// affected.
+//
+// The tree is traversed in a single pass; the runtime complexity is O(N) plus
+// O(log N) for each boundary that changes.
+func (t *T[B, P]) ReplaceWhere(pred func(p P) bool, fn func(p P) P) {
+	t.updateAll(func(p P) P {
+		if pred(p) {
+			return fn(p)
+		}
+		return p
+	})
+}
+
+// updateAll updates the property of all regions with non-zero property in a
+// single pass.
+func (t *T[B, P]) updateAll(updateProp func(p P) P) {
+	defer t.endOp(t.startOp())
+	start, end, ok := t.Bounds()
+	if !ok {
+		return
+	}
+	var zeroProp P
+	var regions []boundaryProp[B, P]
+	// oldProp is the previous property of the last region in regions.
+	var oldProp P
+	t.tree.AscendFunc(btreemap.GE(start), btreemap.LT(end), func(rStart B, rProp P) bool {
+		if n := len(regions); n > 0 {
+			t.notifyChange(regions[n-1].b, rStart, oldProp, regions[n-1].prop)
+		}
+		newProp := rProp
+		if !t.propEq(rProp, zeroProp) {
+			newProp = updateProp(rProp)
+		}
+		regions = append(regions, boundaryProp[B, P]{b: rStart, prop: newProp})
+		oldProp = rProp
+		return true
+	})
+	t.notifyChange(regions[len(regions)-1].b, end, oldProp, regions[len(regions)-1].prop)
+	t.setRegions(start, end, regions)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestMapBoundaries(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		// Map to int64 boundaries, dividing by a factor.
+		factor := rng.IntN(3) + 1
+		rt2 := MapBoundaries(&rt, func(b int) int64 { return int64(b / factor) }, cmp.Compare[int64])
+		rt2.CheckInvariants()
+		// Map back, to be able to compare with a naiveInts.
+		rt3 := MapBoundaries(&rt2, func(b int64) int { return int(b) }, cmp.Compare[int])
+		rt3.CheckInvariants()
+
+		var expected naiveInts
+		for i := 0; i < maxRange/factor; i++ {
+			// The mapped point i corresponds to original points [i*factor,
+			// (i+1)*factor); the property is that of the last boundary that
+			// maps to i (or the point just before the first one that maps
+			// after i).
+			expected.values[i] = n.values[(i+1)*factor-1]
+		}
+		checkEqual(t, &rt3, &expected, fmt.Sprintf("factor: %d\nseed: %d", factor, seed))
+	}
+}
+
+func TestMapProperties(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		// Map properties to booleans (odd or not).
+		rt2 := MapProperties(&rt, func(p int) bool { return p%2 == 1 }, func(a, b bool) bool { return a == b })
+		rt2.CheckInvariants()
+		rt3 := MapProperties(&rt2, func(p bool) int { return 1 }, func(a, b int) bool { return a == b })
+		rt3.CheckInvariants()
+		for i := range n.values {
+			n.values[i] %= 2
+		}
+		checkEqual(t, &rt3, &n, fmt.Sprintf("seed: %d", seed))
+	}
+}
+
+func TestFilterWhere(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		rt2 := rt.FilterWhere(func(p int) bool { return p >= 2 })
+		rt2.CheckInvariants()
+		for i := range n.values {
+			if n.values[i] < 2 {
+				n.values[i] = 0
+			}
+		}
+		checkEqual(t, &rt2, &n, fmt.Sprintf("seed: %d", seed))
+	}
+}
+
+func TestDeleteWhere(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		rt.DeleteWhere(func(p int) bool { return p%2 == 0 })
+		rt.CheckInvariants()
+		for i := range n.values {
+			if n.values[i]%2 == 0 {
+				n.values[i] = 0
+			}
+		}
+		checkEqual(t, &rt, &n, fmt.Sprintf("seed: %d", seed))
+	}
+}
+
+func TestReplaceWhere(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		rt, n := randomTree(rng, valRange)
+		rt.ReplaceWhere(func(p int) bool { return p%2 == 1 }, func(p int) int { return p + 1 })
+		rt.CheckInvariants()
+		for i := range n.values {
+			if n.values[i]%2 == 1 {
+				n.values[i]++
+			}
+		}
+		checkEqual(t, &rt, &n, fmt.Sprintf("seed: %d", seed))
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+// Txn stages a set of modifications to a region tree, which are either all
+// applied with Commit or all discarded with Abort. A Txn is created with
+// T.Begin.
+//
+// The staged modifications are not visible in the tree until Commit; the
+// queries on the Txn reflect the tree with the staged modifications applied.
+// The tree must not be modified while the Txn is open.
+type Txn[B Boundary, P Property] struct {
+	t *T[B, P]
+	// pending is a lazy clone of the tree, with the staged modifications
+	// applied.
+	pending T[B, P]
+	done    bool
+}
+
+// Begin starts a transaction on the tree. The transaction must be ended with
+// either Commit or Abort.
+//
+// Begin is a constant time operation (it uses Clone).
+func (t *T[B, P]) Begin() *Txn[B, P] {
+	return &Txn[B, P]{t: t, pending: t.Clone()}
+}
+
+// Update stages an update of the property for the given range. See T.Update.
+func (txn *Txn[B, P]) Update(start, end B, updateProp func(p P) P) (changed bool) {
+	return txn.tree().Update(start, end, updateProp)
+}
+
+// Set stages setting the property for the given range. See T.Set.
+func (txn *Txn[B, P]) Set(start, end B, prop P) {
+	txn.tree().Set(start, end, prop)
+}
+
+// Delete stages the deletion of the given range. See T.Delete.
+func (txn *Txn[B, P]) Delete(start, end B) {
+	txn.tree().Delete(start, end)
+}
+
+// ApplyBatch stages a batch of updates. See T.ApplyBatch.
+func (txn *Txn[B, P]) ApplyBatch(updates []RangeUpdate[B, P]) {
+	txn.tree().ApplyBatch(updates)
+}
+
+// Enumerate is the equivalent of T.Enumerate, reflecting the staged
+// modifications.
+func (txn *Txn[B, P]) Enumerate(start, end B, emit func(start, end B, prop P) bool) {
+	txn.tree().Enumerate(start, end, emit)
+}
+
+// Any is the equivalent of T.Any, reflecting the staged modifications.
+func (txn *Txn[B, P]) Any(start, end B, propFn func(prop P) bool) bool {
+	return txn.tree().Any(start, end, propFn)
+}
+
+// Overlaps is the equivalent of T.Overlaps, reflecting the staged
+// modifications.
+func (txn *Txn[B, P]) Overlaps(start, end B) bool {
+	return txn.tree().Overlaps(start, end)
+}
+
+// Freeze returns a read-only view of the tree with the staged modifications
+// applied (as they are now). See T.Freeze.
+func (txn *Txn[B, P]) Freeze() Frozen[B, P] {
+	return txn.tree().Freeze()
+}
+
+// Commit applies all the staged modifications to the tree and ends the
+// transaction. The OnChange hook of the tree (if set) is invoked for the
+// regions that changed.
+//
+// The runtime complexity is O(1), or O(N) if the tree has an OnChange hook.
+func (txn *Txn[B, P]) Commit() {
+	t := txn.tree()
+	txn.done = true
+	txn.t.recordSnapshot()
+	txn.t.restoreSnapshot(t.tree)
+	txn.pending = T[B, P]{}
+}
+
+// Abort discards all the staged modifications and ends the transaction.
+func (txn *Txn[B, P]) Abort() {
+	txn.tree()
+	txn.done = true
+	txn.pending = T[B, P]{}
+}
+
+// tree returns the tree with the staged modifications. Panics if the
+// transaction was already ended.
+func (txn *Txn[B, P]) tree() *T[B, P] {
+	if txn.done {
+		panic("transaction already ended")
+	}
+	return &txn.pending
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestTxn(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+		// mirror is maintained through the OnChange hook.
+		var mirror naiveInts
+		opts := Options[int, int]{
+			OnChange: func(start, end int, oldProp, newProp int) {
+				for i := start; i < end; i++ {
+					mirror.values[i] = newProp
+				}
+			},
+		}
+		rt := MakeWithOptions[int, int](cmp.Compare[int], func(a, b int) bool { return a == b }, opts)
+		var n naiveInts
+		for i, numTxns := 0, rng.IntN(10); i < numTxns; i++ {
+			txn := rt.Begin()
+			staged := n
+			for j, numOps := 0, rng.IntN(20); j < numOps; j++ {
+				a, b := rng.IntN(valRange), rng.IntN(valRange)
+				if a > b {
+					a, b = b, a
+				}
+				value := rng.IntN(5)
+				switch rng.IntN(3) {
+				case 0:
+					txn.Update(a, b, func(p int) int { return p + value })
+					staged.Add(a, b, value)
+				case 1:
+					txn.Set(a, b, value)
+					staged.Set(a, b, value)
+				case 2:
+					txn.Delete(a, b)
+					staged.Set(a, b, 0)
+				}
+				context := fmt.Sprintf("seed: %d", seed)
+				f := txn.Freeze()
+				checkEqual(t, &f.t, &staged, context)
+				// The tree must not reflect the staged modifications.
+				checkEqual(t, &rt, &n, context)
+				a = rng.IntN(valRange)
+				if txn.Overlaps(a, valRange) != staged.Any(a, valRange, func(p int) bool { return p != 0 }) {
+					t.Fatalf("incorrect Overlaps(%d, %d)\n%s", a, valRange, context)
+				}
+			}
+			if rng.IntN(2) == 0 {
+				txn.Commit()
+				n = staged
+			} else {
+				txn.Abort()
+			}
+			rt.CheckInvariants()
+			checkEqual(t, &rt, &n, fmt.Sprintf("seed: %d", seed))
+			checkEqual(t, &rt, &mirror, fmt.Sprintf("seed: %d", seed))
+		}
+	}
+}
+
+func TestTxnEnded(t *testing.T) {
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	txn := rt.Begin()
+	txn.Set(1, 10, 1)
+	txn.Commit()
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	txn.Set(1, 10, 2)
+}
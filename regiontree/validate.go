@@ -0,0 +1,73 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import "fmt"
+
+// validationSnapshot returns a clone of the tree if the Validate option is
+// set, or nil otherwise. The snapshot is passed to validateMutation after the
+// mutation.
+func (t *T[B, P]) validationSnapshot() *T[B, P] {
+	if !t.opts.Validate {
+		return nil
+	}
+	c := t.Clone()
+	return &c
+}
+
+// validateMutation checks the invariants of the tree and verifies that the
+// tree differs from the snapshot only inside [start, end).
+func (t *T[B, P]) validateMutation(snapshot *T[B, P], start, end B) {
+	t.CheckInvariants()
+	snapshot.Diff(t, func(dStart, dEnd B, oldProp, newProp P) bool {
+		if t.cmp(dStart, start) < 0 || t.cmp(dEnd, end) > 0 {
+			panic(fmt.Sprintf(
+				"mutation of [%v, %v) changed the property outside of the range: [%v, %v) %v -> %v",
+				start, end, dStart, dEnd, oldProp, newProp,
+			))
+		}
+		return true
+	})
+}
+
+// validateUnchanged verifies that the tree has the same regions as the
+// snapshot.
+func (t *T[B, P]) validateUnchanged(snapshot *T[B, P], start, end B) {
+	snapshot.Diff(t, func(dStart, dEnd B, oldProp, newProp P) bool {
+		panic(fmt.Sprintf(
+			"mutation of [%v, %v) reported no change but changed [%v, %v) %v -> %v",
+			start, end, dStart, dEnd, oldProp, newProp,
+		))
+	})
+}
+
+// validateRegions verifies that the regions in [start, end) match the given
+// regions (which are in the format expected by setRegions).
+func (t *T[B, P]) validateRegions(start, end B, regions []boundaryProp[B, P]) {
+	i := 0
+	zip(t, t, start, end, func(fStart, fEnd B, prop, _ P) bool {
+		// Find the last region that starts at or before fStart.
+		for i+1 < len(regions) && t.cmp(regions[i+1].b, fStart) <= 0 {
+			i++
+		}
+		if !t.equivalent(prop, regions[i].prop) {
+			panic(fmt.Sprintf(
+				"mutation of [%v, %v) resulted in [%v, %v) = %v; expected %v",
+				start, end, fStart, fEnd, prop, regions[i].prop,
+			))
+		}
+		return true
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/RaduBerinde/axisds"
+)
+
+// Versioned is a region tree where each modification is tagged with a version,
+// and which can be queried "as of" any past version.
+//
+// Versions must be non-decreasing across modifications. The state as of
+// version v reflects all the modifications with versions up to and including
+// v. A snapshot of the tree is retained (using the cheap copy-on-write Clone)
+// every time the version advances; old snapshots can be discarded with
+// DiscardBefore.
+type Versioned[B Boundary, P Property] struct {
+	cur     T[B, P]
+	latest  uint64
+	started bool
+	// history contains the state as of each version before latest (only for
+	// versions which had modifications), in increasing version order.
+	history []versionSnapshot[B, P]
+	// discarded is set if DiscardBefore removed snapshots; in that case, the
+	// state before the first snapshot is unknown.
+	discarded bool
+}
+
+type versionSnapshot[B Boundary, P Property] struct {
+	version uint64
+	f       Frozen[B, P]
+}
+
+// MakeVersioned creates a new versioned region tree with the given boundary
+// and property comparison functions.
+func MakeVersioned[B Boundary, P Property](
+	cmp axisds.CompareFn[B], propEq PropertyEqualFn[P],
+) Versioned[B, P] {
+	return Versioned[B, P]{cur: Make(cmp, propEq)}
+}
+
+// Update the property for the given range, at the given version. See
+// T.Update.
+func (v *Versioned[B, P]) Update(version uint64, start, end B, updateProp func(p P) P) {
+	v.advance(version)
+	v.cur.Update(start, end, updateProp)
+}
+
+// Set the property for the given range, at the given version. See T.Set.
+func (v *Versioned[B, P]) Set(version uint64, start, end B, prop P) {
+	v.advance(version)
+	v.cur.Set(start, end, prop)
+}
+
+// Delete the given range (setting the property to zero), at the given
+// version. See T.Delete.
+func (v *Versioned[B, P]) Delete(version uint64, start, end B) {
+	v.advance(version)
+	v.cur.Delete(start, end)
+}
+
+// advance prepares for a modification at the given version, taking a snapshot
+// of the current state if the version advances.
+func (v *Versioned[B, P]) advance(version uint64) {
+	if v.started && version < v.latest {
+		panic("versions must be non-decreasing")
+	}
+	if v.started && version > v.latest {
+		v.history = append(v.history, versionSnapshot[B, P]{
+			version: v.latest,
+			f:       v.cur.Freeze(),
+		})
+	}
+	v.latest = version
+	v.started = true
+}
+
+// Latest returns the version of the most recent modification, or false if
+// there were no modifications.
+func (v *Versioned[B, P]) Latest() (version uint64, ok bool) {
+	return v.latest, v.started
+}
+
+// AsOf returns a read-only view of the tree as of the given version, i.e.
+// reflecting all the modifications with versions up to and including the given
+// version.
+//
+// AsOf panics if the version is older than what was retained by
+// DiscardBefore.
+//
+// The runtime complexity is O(log V) where V is the number of retained
+// versions.
+func (v *Versioned[B, P]) AsOf(version uint64) Frozen[B, P] {
+	if !v.started || version >= v.latest {
+		return v.cur.Freeze()
+	}
+	i, found := slices.BinarySearchFunc(v.history, version, func(s versionSnapshot[B, P], version uint64) int {
+		return cmp.Compare(s.version, version)
+	})
+	if found {
+		return v.history[i].f
+	}
+	// v.history[i-1] is the last snapshot before version.
+	if i == 0 {
+		if v.discarded {
+			panic("version was discarded")
+		}
+		e := v.cur.newEmpty()
+		return e.Freeze()
+	}
+	return v.history[i-1].f
+}
+
+// DiscardBefore discards the snapshots that are only needed for AsOf queries
+// with versions older than the given version.
+func (v *Versioned[B, P]) DiscardBefore(version uint64) {
+	if v.started && version >= v.latest {
+		v.discarded = v.discarded || len(v.history) > 0
+		v.history = nil
+		return
+	}
+	// Find the last snapshot that is at or before version; we need to keep it.
+	i := 0
+	for i < len(v.history) && v.history[i].version <= version {
+		i++
+	}
+	if i > 1 {
+		v.history = slices.Delete(v.history, 0, i-1)
+		v.discarded = true
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestVersioned(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		seed := rand.Uint64()
+		rng := rand.New(rand.NewPCG(seed, seed))
+		valRange := rng.IntN(100) + 1
+
+		v := MakeVersioned[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+		// expected[i] is the expected state as of version i.
+		var expected []naiveInts
+		version := 0
+		discardedBefore := 0
+		for op := 0; op < 50; op++ {
+			if rng.IntN(3) == 0 {
+				version += rng.IntN(3)
+			}
+			for len(expected) <= version {
+				if len(expected) == 0 {
+					expected = append(expected, naiveInts{})
+				} else {
+					expected = append(expected, expected[len(expected)-1])
+				}
+			}
+			n := &expected[version]
+			a, b := rng.IntN(valRange), rng.IntN(valRange)
+			if a > b {
+				a, b = b, a
+			}
+			value := rng.IntN(5)
+			switch rng.IntN(4) {
+			case 0:
+				v.Update(uint64(version), a, b, func(p int) int { return p + value })
+				n.Add(a, b, value)
+			case 1:
+				v.Set(uint64(version), a, b, value)
+				n.Set(a, b, value)
+			case 2:
+				v.Delete(uint64(version), a, b)
+				n.Set(a, b, 0)
+			case 3:
+				discardedBefore = max(discardedBefore, rng.IntN(version+1))
+				v.DiscardBefore(uint64(discardedBefore))
+			}
+
+			asOf := discardedBefore + rng.IntN(version+2-discardedBefore)
+			f := v.AsOf(uint64(asOf))
+			rt := f.Thaw()
+			context := fmt.Sprintf("as of %d (latest %d)\nseed: %d", asOf, version, seed)
+			checkEqual(t, &rt, &expected[min(asOf, version)], context)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// visualizeChars are the characters used for the property classes in
+// Visualize; any additional classes use visualizeOverflowChar.
+const visualizeChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const visualizeOverflowChar = '#'
+
+// Visualize renders the regions in [start, end) as a fixed-width ASCII bar, for
+// debugging. The measure function is used to map the range to width columns;
+// each column shows the property that covers most of it. Regions with zero
+// property are shown as '.'; each distinct property (according to
+// PropertyEqualFn) is shown as a different letter. The bar is followed by a
+// legend with one line per property, for example:
+//
+//	AAAB....
+//	A: 10
+//	B: 20
+//
+// The runtime complexity is O(log N + K + width) where K is the number of
+// regions in the range.
+func (t *T[B, P]) Visualize(start, end B, width int, measure MeasureFn[B]) string {
+	t = t.atNow()
+	if width <= 0 || t.cmp(start, end) >= 0 {
+		return ""
+	}
+	total := measure(start, end)
+	colWidth := total / float64(width)
+
+	// classes contains the distinct properties, in order of appearance.
+	var classes []P
+	classOf := func(prop P) int {
+		for i := range classes {
+			if t.propEq(classes[i], prop) {
+				return i
+			}
+		}
+		classes = append(classes, prop)
+		return len(classes) - 1
+	}
+
+	// coverage[c] contains the coverage of each property class in column c.
+	coverage := make([]map[int]float64, width)
+	t.Enumerate(start, end, func(rStart, rEnd B, prop P) bool {
+		class := classOf(prop)
+		s, e := measure(start, rStart), measure(start, rEnd)
+		firstCol := max(int(s/colWidth), 0)
+		lastCol := min(int(math.Ceil(e/colWidth)), width)
+		for c := firstCol; c < lastCol; c++ {
+			colStart, colEnd := float64(c)*colWidth, float64(c+1)*colWidth
+			if overlap := min(e, colEnd) - max(s, colStart); overlap > 0 {
+				if coverage[c] == nil {
+					coverage[c] = make(map[int]float64)
+				}
+				coverage[c][class] += overlap
+			}
+		}
+		return true
+	})
+
+	var b strings.Builder
+	for c := range coverage {
+		// The zero property covers whatever the other classes don't.
+		bestClass, bestCoverage := -1, colWidth
+		for _, cov := range coverage[c] {
+			bestCoverage -= cov
+		}
+		for class, cov := range coverage[c] {
+			if cov > bestCoverage || (cov == bestCoverage && class < bestClass) {
+				bestClass, bestCoverage = class, cov
+			}
+		}
+		switch {
+		case bestClass == -1:
+			b.WriteByte('.')
+		case bestClass < len(visualizeChars):
+			b.WriteByte(visualizeChars[bestClass])
+		default:
+			b.WriteByte(visualizeOverflowChar)
+		}
+	}
+	b.WriteByte('\n')
+	for i := range classes {
+		ch := byte(visualizeOverflowChar)
+		if i < len(visualizeChars) {
+			ch = visualizeChars[i]
+		}
+		fmt.Fprintf(&b, "%c: %v\n", ch, classes[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Radu Berinde.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regiontree
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestVisualize(t *testing.T) {
+	rt := Make[int, int](cmp.Compare[int], func(a, b int) bool { return a == b })
+	rt.Set(0, 10, 1)
+	rt.Set(10, 20, 2)
+	rt.Set(26, 28, 1)
+	rt.Set(30, 33, 3)
+
+	for _, tc := range []struct {
+		start, end, width int
+		expected          string
+	}{
+		{0, 40, 20, "AAAAABBBBB...A.C....\nA: 1\nB: 2\nC: 3\n"},
+		{0, 40, 8, "AABB..C.\nA: 1\nB: 2\nC: 3\n"},
+		{0, 40, 4, "AB..\nA: 1\nB: 2\nC: 3\n"},
+		{10, 30, 4, "AA..\nA: 2\nB: 1\n"},
+		{40, 50, 5, ".....\n"},
+		{10, 10, 5, ""},
+	} {
+		if actual := rt.Visualize(tc.start, tc.end, tc.width, intMeasure); actual != tc.expected {
+			t.Errorf("Visualize(%d, %d, %d):\n%sexpected:\n%s", tc.start, tc.end, tc.width, actual, tc.expected)
+		}
+	}
+}